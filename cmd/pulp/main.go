@@ -1,10 +1,25 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sant0-9/pulp/internal/batch"
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/dryrun"
+	"github.com/sant0-9/pulp/internal/llm"
+	"github.com/sant0-9/pulp/internal/output"
+	"github.com/sant0-9/pulp/internal/serve"
+	"github.com/sant0-9/pulp/internal/skill"
 	"github.com/sant0-9/pulp/internal/tui"
 )
 
@@ -24,7 +39,29 @@ func main() {
 		case "--help", "-h", "help":
 			printHelp()
 			return
+		case "serve":
+			if err := runServe(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "batch":
+			if err := runBatch(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	// --dry-run can go anywhere: pulp report.pdf --dry-run "summarize".
+	// Batch takes it too, see runBatch.
+	if args, ok := cutFlag(os.Args[1:], "--dry-run"); ok {
+		if err := runDryRun(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
+		return
 	}
 
 	app := tui.NewApp()
@@ -47,14 +84,269 @@ func printHelp() {
 Usage:
   pulp [flags]
   pulp [file]
+  pulp --dry-run [--hierarchical] <file> [instruction]
+  pulp serve [--addr host:port]
+  pulp batch [--dry-run] [-i instruction] [--template file] [--output target] <files or dirs...>
 
 Flags:
   -h, --help      Show this help
   -v, --version   Show version
+  --dry-run       Chunk and build prompts, print them, make no LLM calls
 
 Examples:
   pulp                    Start interactive mode
   pulp document.pdf       Open with a document
+  pulp --dry-run report.pdf "summarize for execs"
+                          Show the calls, tokens and prompts a run would use
+  pulp serve              Share this host over HTTP (see serve.tokens in config)
+  pulp batch -i "key decisions" --out digest.md ~/Reports/week42
+                          One report with every document's result and a roll-up
 
 For more info: https://github.com/sant0-9/pulp`)
 }
+
+// interruptContext is canceled by Ctrl-C or SIGTERM, which cancels the
+// pipeline and writer calls in flight. A second Ctrl-C exits at once.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "", "address to listen on (default "+serve.DefaultAddr+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("no config found, run pulp once to set up a provider")
+	}
+
+	provider, err := llm.NewProvider(cfg)
+	if err != nil {
+		return err
+	}
+
+	if *addr == "" {
+		*addr = serve.DefaultAddr
+		if cfg.Serve != nil && cfg.Serve.Addr != "" {
+			*addr = cfg.Serve.Addr
+		}
+	}
+
+	srv := serve.NewServer(cfg, provider)
+	fmt.Println(srv.Banner(*addr))
+	return srv.ListenAndServe(*addr)
+}
+
+// cutFlag removes every occurrence of a boolean flag from args and
+// reports whether it was there
+func cutFlag(args []string, name string) ([]string, bool) {
+	var rest []string
+	found := false
+	for _, arg := range args {
+		if arg == name {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+func runDryRun(args []string) error {
+	fs := flag.NewFlagSet("dry-run", flag.ContinueOnError)
+	hierarchical := fs.Bool("hierarchical", false, "plan in the hierarchical mode used for huge documents")
+
+	// Flags may come before, between or after the file and instruction
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) < 1 {
+		return fmt.Errorf("usage: pulp --dry-run [--hierarchical] <file> [instruction]")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	doc, err := converter.Load(context.Background(), positional[0], converter.LoadOptions{
+		Transcription: cfg.TranscriptionSettings(),
+	})
+	if err != nil {
+		return err
+	}
+
+	skillIdx, _ := skill.NewSkillIndex()
+	gen := cfg.GenerationSettings()
+	plan := dryrun.Build(doc, dryrun.Options{
+		Model:        cfg.Model,
+		Instruction:  strings.Join(positional[1:], " "),
+		SkillIndex:   skillIdx,
+		Hierarchical: *hierarchical,
+		Language:     cfg.ExtractionLanguage,
+		Preamble:     cfg.Preamble,
+		Generation:   &gen,
+	})
+
+	fmt.Print(plan.Report())
+	return nil
+}
+
+func runBatch(args []string) error {
+	// Like the single-document --dry-run, it can go anywhere
+	args, dryRun := cutFlag(args, "--dry-run")
+
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	instruction := fs.String("i", "Summarize the key points", "instruction applied to every document")
+	title := fs.String("title", "", "report title (default \"Batch Report\")")
+	templatePath := fs.String("template", "", "text/template file for the report (default: built-in markdown)")
+	out := fs.String("out", "", "write the report to this file instead of stdout (same as --output file:<file>)")
+	var targets []string
+	fs.Func("output", "send the report to an output: a name from outputs: in the config, stdout, clipboard, file:<path>, webhook:<url> or command:<program> (repeatable)", func(s string) error {
+		targets = append(targets, s)
+		return nil
+	})
+	noRollup := fs.Bool("no-rollup", false, "skip the overall roll-up summary")
+	hierarchical := fs.Bool("hierarchical", false, "process documents over the size limits in hierarchical mode instead of failing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 {
+		return fmt.Errorf("usage: pulp batch [--dry-run] [-i instruction] [--template file] [--output target] <files or dirs...>")
+	}
+	if *out != "" {
+		targets = append(targets, "file:"+*out)
+	}
+	if len(targets) == 0 {
+		targets = []string{"stdout"}
+	}
+
+	files, err := batch.ExpandPaths(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	// Check the template before spending any LLM calls
+	var text string
+	if *templatePath != "" {
+		data, err := os.ReadFile(*templatePath)
+		if err != nil {
+			return err
+		}
+		text = string(data)
+	}
+	tmpl, err := batch.ParseTemplate(text)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg == nil && !dryRun {
+		return fmt.Errorf("no config found, run pulp once to set up a provider")
+	}
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	skillIdx, _ := skill.NewSkillIndex()
+	opts := batch.Options{
+		Model:        cfg.Model,
+		Instruction:  *instruction,
+		Title:        *title,
+		SkillIndex:   skillIdx,
+		Generation:   cfg.GenerationSettings(),
+		Load:         converter.LoadOptions{Transcription: cfg.TranscriptionSettings()},
+		Language:     cfg.ExtractionLanguage,
+		Preamble:     cfg.Preamble,
+		NoRollup:     *noRollup,
+		Limits:       cfg.DocLimits(),
+		Hierarchical: *hierarchical,
+		OnProgress: func(i, total int, path string) {
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s\n", i+1, total, path)
+		},
+	}
+
+	if dryRun {
+		plan, failed := batch.Plan(context.Background(), files, opts)
+		for _, d := range failed {
+			fmt.Fprintf(os.Stderr, "Would fail: %s: %v\n", d.Path, d.Err)
+		}
+		fmt.Print(plan.Report())
+		return nil
+	}
+
+	// Outputs are checked up front as well
+	var sinks []output.Sink
+	for _, t := range targets {
+		sink, err := output.Open(cfg, t)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	provider, err := llm.NewProvider(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	report, err := batch.Run(ctx, provider, files, opts)
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("interrupted after %d of %d documents", len(report.Documents), len(files))
+	}
+	if report == nil {
+		return err
+	}
+	for _, d := range report.Documents {
+		if d.Err != nil {
+			fmt.Fprintf(os.Stderr, "Failed: %s: %v\n", d.Path, d.Err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := batch.Render(&buf, report, tmpl); err != nil {
+		return err
+	}
+	res := output.Result{Title: report.Title, Content: buf.String()}
+	for _, sink := range sinks {
+		where, err := sink.Send(ctx, res)
+		if err != nil {
+			return err
+		}
+		if where != "stdout" {
+			fmt.Fprintf(os.Stderr, "Report sent to %s\n", where)
+		}
+	}
+	return nil
+}
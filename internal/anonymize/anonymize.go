@@ -0,0 +1,319 @@
+// Package anonymize makes redacted copies of documents: people,
+// organizations, amounts and contact details are replaced with numbered
+// placeholders so the copy can be shared or sent to cloud models.
+package anonymize
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/llm"
+	"github.com/sant0-9/pulp/internal/prompts"
+)
+
+// Document returns a redacted copy of doc and the number of distinct
+// entities replaced. With a provider, people and organizations are also
+// found by the model; without one only the patterns in Detect are used.
+func Document(ctx context.Context, doc *converter.Document, provider llm.Provider, model string) (*converter.Document, int, error) {
+	entities := Detect(doc.Content)
+	if provider != nil {
+		found, err := DetectWithModel(ctx, provider, model, doc.Content)
+		if err != nil {
+			return nil, 0, err
+		}
+		entities = append(entities, found...)
+	}
+
+	r := NewRedactor(entities)
+	copied := converter.FromMarkdown(r.Redact(doc.Content), "")
+	copied.Metadata.Title = r.Redact(doc.Metadata.Title) + " (anonymized)"
+	copied.Metadata.SourceFormat = doc.Metadata.SourceFormat
+	copied.Metadata.PageCount = doc.Metadata.PageCount
+	return copied, r.Count(), nil
+}
+
+// Kind is the type of a redacted entity, used in its placeholder
+type Kind string
+
+const (
+	Person Kind = "PERSON"
+	Org    Kind = "ORG"
+	Amount Kind = "AMOUNT"
+	Email  Kind = "EMAIL"
+	Phone  Kind = "PHONE"
+)
+
+// Entity is a piece of text to redact
+type Entity struct {
+	Text string
+	Kind Kind
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	phonePattern = regexp.MustCompile(`\+?\(?\d[\d ().-]{7,}\d`)
+
+	// $1,200, €3.5M, 2 million dollars, USD 400k, 12,000 EUR
+	amountPattern = regexp.MustCompile(`(?i)[$€£¥]\s?\d[\d,.]*(?:\s?(?:million|billion|thousand|bn|[kmb])\b)?` +
+		`|\b(?:USD|EUR|GBP|CHF|JPY)\s?\d[\d,.]*(?:\s?(?:million|billion|thousand|bn|[kmb])\b)?` +
+		`|\b\d[\d,.]*\s?(?:million|billion|thousand)?\s?(?:USD|EUR|GBP|CHF|JPY|dollars|euros|pounds)\b`)
+
+	// Capitalized words ending in a company suffix: Acme Corp, Globex Holdings
+	orgPattern = regexp.MustCompile(`\b(?:[A-Z][\w&'-]*[ \t]+){1,4}(?:Inc|LLC|Ltd|Corp|Corporation|GmbH|AG|SA|PLC|LLP|Co|Company|Group|Holdings|Bank|Partners|Foundation|University|Institute|Agency)\b\.?`)
+
+	// Mr. Smith, Dr Jane Doe
+	honorificPattern = regexp.MustCompile(`\b(?:Mr|Mrs|Ms|Mx|Dr|Prof|Sir|Dame)\.?[ \t]+([A-Z][\p{L}'-]+(?:[ \t]+[A-Z][\p{L}'-]+)?)`)
+
+	// Name: Jane Doe / Attendees: Jane Doe, John Roe
+	nameFieldPattern = regexp.MustCompile(`(?im)^[\s*_-]*(?:name|contact|from|to|cc|attendees?|participants?|present|signed|prepared by|author|owner|assignee)[*_]*\s*:[*_]*\s*(.+)$`)
+	fullNamePattern  = regexp.MustCompile(`^[A-Z][\p{L}'-]+(?:\s+[A-Z]\.)?(?:\s+[A-Z][\p{L}'-]+){1,2}$`)
+)
+
+// Detect finds entities with patterns: contact details, amounts,
+// company names with a legal suffix and people introduced by a title or
+// listed in a name field. Names without such cues need DetectWithModel.
+func Detect(text string) []Entity {
+	var entities []Entity
+	add := func(kind Kind, s string) {
+		s = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(s), ".,;:"))
+		if s != "" {
+			entities = append(entities, Entity{Text: s, Kind: kind})
+		}
+	}
+
+	for _, m := range emailPattern.FindAllString(text, -1) {
+		add(Email, m)
+	}
+	for _, m := range phonePattern.FindAllString(text, -1) {
+		if n := countDigits(m); n >= 9 && n <= 15 {
+			add(Phone, m)
+		}
+	}
+	for _, m := range amountPattern.FindAllString(text, -1) {
+		add(Amount, m)
+	}
+	for _, m := range orgPattern.FindAllString(text, -1) {
+		add(Org, strings.TrimPrefix(m, "The "))
+	}
+	for _, m := range honorificPattern.FindAllStringSubmatch(text, -1) {
+		add(Person, m[1])
+	}
+	for _, m := range nameFieldPattern.FindAllStringSubmatch(text, -1) {
+		for _, name := range strings.FieldsFunc(m[1], func(r rune) bool { return r == ',' || r == ';' }) {
+			name = strings.Trim(strings.TrimSpace(name), "*_")
+			name = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(name, "and "), "."))
+			if fullNamePattern.MatchString(name) {
+				add(Person, name)
+			}
+		}
+	}
+	return entities
+}
+
+func countDigits(s string) int {
+	n := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// Text sent to the model per request
+const modelChunkSize = 4000
+
+// DetectWithModel asks a model for the people and organizations in
+// text. Use a local model: the point is not to send the original
+// anywhere else.
+func DetectWithModel(ctx context.Context, provider llm.Provider, model, text string) ([]Entity, error) {
+	var entities []Entity
+	for _, part := range split(text, modelChunkSize) {
+		resp, err := provider.Complete(ctx, &llm.CompletionRequest{
+			Model: model,
+			Messages: []llm.Message{
+				{Role: "system", Content: prompts.Anonymize},
+				{Role: "user", Content: part},
+			},
+			MaxTokens:   800,
+			Temperature: 0,
+			JSON:        llm.CapabilitiesOf(provider).JSONMode,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("entity detection failed: %w", err)
+		}
+
+		var found struct {
+			People        []string `json:"people"`
+			Organizations []string `json:"organizations"`
+		}
+		content := resp.Content
+		if start, end := strings.Index(content, "{"), strings.LastIndex(content, "}"); start >= 0 && end > start {
+			content = content[start : end+1]
+		}
+		if err := json.Unmarshal([]byte(content), &found); err != nil {
+			continue // Patterns still cover this part
+		}
+		for _, p := range found.People {
+			// Models sometimes list names that aren't in the text
+			if strings.Contains(part, p) {
+				entities = append(entities, Entity{Text: strings.TrimSpace(p), Kind: Person})
+			}
+		}
+		for _, o := range found.Organizations {
+			if strings.Contains(part, o) {
+				entities = append(entities, Entity{Text: strings.TrimSpace(o), Kind: Org})
+			}
+		}
+	}
+	return entities, nil
+}
+
+// split cuts text into pieces of about size characters at paragraph breaks
+func split(text string, size int) []string {
+	var parts []string
+	var b strings.Builder
+	for _, para := range strings.Split(text, "\n\n") {
+		if b.Len() > 0 && b.Len()+len(para) > size {
+			parts = append(parts, b.String())
+			b.Reset()
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(para)
+	}
+	if strings.TrimSpace(b.String()) != "" {
+		parts = append(parts, b.String())
+	}
+	return parts
+}
+
+// Redactor replaces entities with numbered placeholders like
+// [PERSON 1]. Every mention of an entity gets the same placeholder, and
+// a person's surname alone counts as a mention.
+type Redactor struct {
+	pattern *regexp.Regexp
+	kinds   map[string]Kind
+	aliases map[string]string // Surname -> full name
+
+	placeholders map[string]string
+	counts       map[Kind]int
+}
+
+// NewRedactor creates a redactor for entities
+func NewRedactor(entities []Entity) *Redactor {
+	r := &Redactor{
+		kinds:        make(map[string]Kind),
+		aliases:      make(map[string]string),
+		placeholders: make(map[string]string),
+		counts:       make(map[Kind]int),
+	}
+
+	for _, e := range entities {
+		if utf8.RuneCountInString(e.Text) < 2 {
+			continue
+		}
+		if _, ok := r.kinds[e.Text]; !ok {
+			r.kinds[e.Text] = e.Kind
+		}
+	}
+	for text, kind := range r.kinds {
+		if kind != Person {
+			continue
+		}
+		words := strings.Fields(text)
+		if last := words[len(words)-1]; len(words) > 1 && utf8.RuneCountInString(last) > 2 {
+			if _, taken := r.kinds[last]; !taken {
+				r.aliases[last] = text
+			}
+		}
+	}
+
+	var alts []string
+	for text := range r.kinds {
+		alts = append(alts, text)
+	}
+	for alias := range r.aliases {
+		alts = append(alts, alias)
+	}
+	if len(alts) == 0 {
+		return r
+	}
+	// Longest first, so "Acme Corp" wins over "Acme"
+	sort.Slice(alts, func(i, j int) bool {
+		if len(alts[i]) != len(alts[j]) {
+			return len(alts[i]) > len(alts[j])
+		}
+		return alts[i] < alts[j]
+	})
+	for i, a := range alts {
+		alts[i] = regexp.QuoteMeta(a)
+	}
+	r.pattern = regexp.MustCompile(strings.Join(alts, "|"))
+	return r
+}
+
+// Redact returns text with every entity replaced. Placeholders are
+// numbered in order of first mention across calls.
+func (r *Redactor) Redact(text string) string {
+	if r.pattern == nil {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range r.pattern.FindAllStringIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		if !isBoundary(text, start, end) {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(r.placeholder(text[start:end]))
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// isBoundary reports whether the match at text[start:end] is a whole
+// word, not part of a longer one ("Ann" in "Annual")
+func isBoundary(text string, start, end int) bool {
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+	first, _ := utf8.DecodeRuneInString(text[start:end])
+	if prev, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && isWord(first) && isWord(prev) {
+		return false
+	}
+	lastRune, _ := utf8.DecodeLastRuneInString(text[start:end])
+	if next, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && isWord(lastRune) && isWord(next) {
+		return false
+	}
+	return true
+}
+
+func (r *Redactor) placeholder(match string) string {
+	name := match
+	if full, ok := r.aliases[match]; ok {
+		name = full
+	}
+	if p, ok := r.placeholders[name]; ok {
+		return p
+	}
+	kind := r.kinds[name]
+	r.counts[kind]++
+	p := fmt.Sprintf("[%s %d]", kind, r.counts[kind])
+	r.placeholders[name] = p
+	return p
+}
+
+// Count returns how many distinct entities were replaced so far
+func (r *Redactor) Count() int {
+	return len(r.placeholders)
+}
@@ -0,0 +1,49 @@
+package anonymize
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/converter"
+)
+
+const memo = `# Offer for Dr. Jane Doe
+
+Attendees: Jane Doe, Marcus Webb
+
+Acme Holdings will pay $1.2 million over two years. Doe accepted;
+Webb asked for USD 40,000 more. Annual review in March.
+
+Contact jane.doe@example.com or +1 (555) 123-4567.`
+
+func TestDocument(t *testing.T) {
+	doc := converter.FromMarkdown(memo, "offer.md")
+	got, n, err := Document(context.Background(), doc, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, leak := range []string{"Jane", "Doe", "Webb", "Acme", "1.2", "40,000", "example.com", "555"} {
+		if strings.Contains(got.Content, leak) || strings.Contains(got.Metadata.Title, leak) {
+			t.Errorf("%q not redacted:\n%s", leak, got.Content)
+		}
+	}
+	for _, want := range []string{
+		"# Offer for Dr. [PERSON 1]",
+		"Attendees: [PERSON 1], [PERSON 2]",
+		"[ORG 1] will pay [AMOUNT 1]",
+		"[PERSON 1] accepted;\n[PERSON 2] asked for [AMOUNT 2] more. Annual review",
+		"Contact [EMAIL 1] or [PHONE 1].",
+	} {
+		if !strings.Contains(got.Content, want) {
+			t.Errorf("missing %q in:\n%s", want, got.Content)
+		}
+	}
+	if n != 7 {
+		t.Errorf("replaced %d entities, want 7", n)
+	}
+	if got.Metadata.Title != "Offer for Dr. [PERSON 1] (anonymized)" {
+		t.Errorf("title = %q", got.Metadata.Title)
+	}
+}
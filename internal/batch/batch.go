@@ -0,0 +1,325 @@
+// Package batch runs one instruction over several documents and
+// combines the results, with a roll-up summary, into a single report.
+package batch
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/intent"
+	"github.com/sant0-9/pulp/internal/llm"
+	"github.com/sant0-9/pulp/internal/pipeline"
+	"github.com/sant0-9/pulp/internal/prompts"
+	"github.com/sant0-9/pulp/internal/samples"
+	"github.com/sant0-9/pulp/internal/skill"
+	"github.com/sant0-9/pulp/internal/writer"
+)
+
+//go:embed report.md.tmpl
+var DefaultTemplate string
+
+// Options controls a batch run
+type Options struct {
+	Model       string
+	Instruction string
+	Title       string
+	SkillIndex  *skill.SkillIndex
+	Generation  config.GenerationSettings
+	Load        converter.LoadOptions
+	Language    string // Extraction language, see pipeline.SetLanguage
+	Preamble    string // About the user, see config.Preamble
+
+	// Skip the roll-up summary across documents
+	NoRollup bool
+
+	// Documents over Limits fail unless Hierarchical is set, which
+	// processes them in hierarchical mode like [h] in the TUI. Zero
+	// limits let everything through.
+	Limits       config.LimitsConfig
+	Hierarchical bool
+
+	// Called as each document starts (i is 0-based)
+	OnProgress func(i, total int, path string)
+}
+
+// DocResult is the outcome for one document
+type DocResult struct {
+	Path   string
+	Title  string
+	Result string
+	Err    error
+}
+
+// Report is the data passed to the output template
+type Report struct {
+	Title       string
+	Instruction string
+	GeneratedAt time.Time
+	Documents   []DocResult
+	Summary     string // Roll-up across documents
+	Failed      int
+}
+
+// Run processes every file with the instruction, then writes the roll-up
+func Run(ctx context.Context, provider llm.Provider, files []string, opts Options) (*Report, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no documents to process")
+	}
+
+	report := &Report{
+		Title:       opts.Title,
+		Instruction: opts.Instruction,
+		GeneratedAt: time.Now(),
+	}
+	if report.Title == "" {
+		report.Title = "Batch Report"
+	}
+
+	parser := intent.NewParser(provider, opts.Model, opts.SkillIndex)
+	parsed, err := parser.Parse(ctx, opts.Instruction)
+	if err != nil {
+		parsed = intent.New(opts.Instruction)
+	}
+
+	for i, path := range files {
+		if opts.OnProgress != nil {
+			opts.OnProgress(i, len(files), path)
+		}
+		doc := processOne(ctx, provider, path, parsed, opts)
+		if err := ctx.Err(); err != nil {
+			// Interrupted: report what finished
+			return report, err
+		}
+		if doc.Err != nil {
+			report.Failed++
+		}
+		report.Documents = append(report.Documents, doc)
+	}
+
+	if report.Failed == len(files) {
+		return report, fmt.Errorf("all %d documents failed", len(files))
+	}
+	if !opts.NoRollup && len(files)-report.Failed > 1 {
+		summary, err := rollup(ctx, provider, report, opts.Model)
+		if err != nil {
+			return report, fmt.Errorf("roll-up failed: %w", err)
+		}
+		report.Summary = summary
+	}
+
+	return report, nil
+}
+
+func processOne(ctx context.Context, provider llm.Provider, path string, parsed *intent.Intent, opts Options) DocResult {
+	doc, res, large := load(ctx, path, opts)
+	if res.Err != nil {
+		return res
+	}
+
+	pipe := pipeline.NewPipeline(provider, opts.Model)
+	pipe.SetLanguage(opts.Language)
+	pipe.SetHierarchical(large)
+	result, err := pipe.Process(ctx, doc, parsed)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	w := writer.NewWriter(provider, opts.Model)
+	w.SetParams(opts.Generation.Temperature, opts.Generation.MaxTokens)
+	w.SetPreamble(opts.Preamble)
+	res.Result, res.Err = w.Write(ctx, &writer.WriteRequest{
+		Aggregated: result.Aggregated,
+		Intent:     parsed,
+		DocTitle:   res.Title,
+	})
+	res.Result = strings.TrimSpace(res.Result)
+	return res
+}
+
+// load reads a document for processing and reports whether it is over
+// the size limits. Documents that can't be processed come back with
+// res.Err set.
+func load(ctx context.Context, path string, opts Options) (doc *converter.Document, res DocResult, large bool) {
+	res = DocResult{Path: path, Title: filepath.Base(path)}
+
+	doc, err := converter.Load(ctx, path, opts.Load)
+	if err != nil {
+		res.Err = err
+		return nil, res, false
+	}
+	if doc.Metadata.Title != "" {
+		res.Title = doc.Metadata.Title
+	}
+
+	pages := 0
+	if doc.Metadata.PageCount != nil {
+		pages = *doc.Metadata.PageCount
+	}
+	large = opts.Limits.Exceeds(doc.Metadata.WordCount, pages)
+	if large && !opts.Hierarchical {
+		res.Err = fmt.Errorf("too large to process (%d words, %d pages; limits are %d words, %d pages): rerun with --hierarchical or raise limits in the config",
+			doc.Metadata.WordCount, pages, opts.Limits.MaxWords, opts.Limits.MaxPages)
+	}
+	return doc, res, large
+}
+
+// Plan returns every request a run would send, without calling the LLM
+// (dry run). Documents that would fail are returned with the reason and
+// left out of the plan.
+func Plan(ctx context.Context, files []string, opts Options) (*pipeline.Plan, []DocResult) {
+	plan := &pipeline.Plan{}
+
+	parser := intent.NewParser(nil, opts.Model, opts.SkillIndex)
+	parsed, matchReq := parser.Plan(opts.Instruction)
+	if matchReq != nil {
+		plan.AddCall("skill match", "choose a skill for the instruction", matchReq)
+	}
+
+	w := writer.NewWriter(nil, opts.Model)
+	w.SetParams(opts.Generation.Temperature, opts.Generation.MaxTokens)
+	w.SetPreamble(opts.Preamble)
+
+	var failed []DocResult
+	report := &Report{Instruction: opts.Instruction}
+	for _, path := range files {
+		doc, res, large := load(ctx, path, opts)
+		if res.Err != nil {
+			failed = append(failed, res)
+			continue
+		}
+
+		pipe := pipeline.NewPipeline(nil, opts.Model)
+		pipe.SetLanguage(opts.Language)
+		if parsed.ExtractionLanguage != "" {
+			pipe.SetLanguage(parsed.ExtractionLanguage)
+		}
+		pipe.SetHierarchical(large)
+		for _, c := range pipe.Plan(doc).Calls {
+			plan.AddCall(c.Stage, res.Title+", "+c.Label, c.Request)
+		}
+
+		// The writer prompt embeds extraction output, shown as a placeholder
+		plan.AddCall("write", res.Title, w.Request(&writer.WriteRequest{Intent: parsed, DocTitle: res.Title}))
+
+		res.Result = "[result for this document]"
+		report.Documents = append(report.Documents, res)
+	}
+
+	plan.DocTitle = fmt.Sprintf("%d documents", len(report.Documents))
+	if !opts.NoRollup && len(report.Documents) > 1 {
+		plan.AddCall("roll-up", fmt.Sprintf("%d documents", len(report.Documents)), rollupRequest(report, opts.Model))
+	}
+
+	return plan, failed
+}
+
+// rollupRequest builds the request that summarizes the per-document
+// results
+func rollupRequest(report *Report, model string) *llm.CompletionRequest {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Instruction applied to each document: %s\n", report.Instruction))
+	for _, d := range report.Documents {
+		if d.Err != nil {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("\n---\n\nDocument: %s\n\n%s\n", d.Title, d.Result))
+	}
+
+	return &llm.CompletionRequest{
+		Model: model,
+		Messages: []llm.Message{
+			{Role: "system", Content: prompts.BatchRollup},
+			{Role: "user", Content: b.String()},
+		},
+		MaxTokens:   1000,
+		Temperature: 0.3,
+	}
+}
+
+// rollup summarizes the per-document results
+func rollup(ctx context.Context, provider llm.Provider, report *Report, model string) (string, error) {
+	resp, err := provider.Complete(ctx, rollupRequest(report, model))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(resp.Content), nil
+}
+
+// ParseTemplate parses a report template; empty text uses
+// DefaultTemplate. Templates get a Report and can use {{inc $i}} for
+// 1-based numbering.
+func ParseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+	t, err := template.New("report").Funcs(template.FuncMap{
+		"inc": func(i int) int { return i + 1 },
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return t, nil
+}
+
+// Render writes the report through the template
+func Render(w io.Writer, report *Report, t *template.Template) error {
+	return t.Execute(w, report)
+}
+
+// ExpandPaths returns the documents named by args. Directories expand
+// to the supported files directly inside them.
+func ExpandPaths(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		if samples.IsSample(arg) {
+			files = append(files, arg)
+			continue
+		}
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, err
+		}
+		var found []string
+		for _, e := range entries {
+			if !e.IsDir() && supported(e.Name()) {
+				found = append(found, filepath.Join(arg, e.Name()))
+			}
+		}
+		sort.Strings(found)
+		files = append(files, found...)
+	}
+	return files, nil
+}
+
+// Document extensions picked up from directories
+var extensions = []string{".pdf", ".docx", ".doc", ".md", ".txt", ".html", ".htm", ".rtf", ".odt", ".eml"}
+
+func supported(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return converter.IsAudio(name)
+}
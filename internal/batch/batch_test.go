@@ -0,0 +1,91 @@
+package batch
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/llm"
+	"github.com/sant0-9/pulp/internal/prompts"
+)
+
+// stubProvider answers extraction, writing and roll-up requests
+type stubProvider struct{}
+
+func (stubProvider) Name() string                   { return "stub" }
+func (stubProvider) Ping(ctx context.Context) error { return nil }
+func (stubProvider) Stream(ctx context.Context, req *llm.CompletionRequest) (<-chan llm.StreamEvent, error) {
+	return nil, nil
+}
+func (stubProvider) Complete(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	switch req.Messages[0].Content {
+	case prompts.Extraction:
+		return &llm.CompletionResponse{Content: `{"key_points":["point"],"summary":"chunk summary"}`}, nil
+	case prompts.BatchRollup:
+		return &llm.CompletionResponse{Content: "Both documents agree."}, nil
+	}
+	return &llm.CompletionResponse{Content: "Result for document."}, nil
+}
+
+func TestRunAndRender(t *testing.T) {
+	files := []string{"pulp://samples/launch-memo.md", "pulp://samples/missing.md", "pulp://samples/meeting-notes.md"}
+	report, err := Run(context.Background(), stubProvider{}, files, Options{Instruction: "summarize"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Failed != 1 || report.Summary != "Both documents agree." {
+		t.Errorf("report = %+v", report)
+	}
+
+	tmpl, err := ParseTemplate("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	if err := Render(&b, report, tmpl); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{"# Batch Report", "## Overview\n\nBoth documents agree.", "## 1. ", "Result for document.", "## 2. missing.md\n\n_Failed: "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := ParseTemplate("{{.Nope"); err == nil {
+		t.Error("ParseTemplate() accepted a broken template")
+	}
+}
+
+func TestRunChecksSizeLimits(t *testing.T) {
+	files := []string{"pulp://samples/launch-memo.md"}
+	opts := Options{Instruction: "summarize", Limits: config.LimitsConfig{MaxWords: 5}}
+
+	report, err := Run(context.Background(), stubProvider{}, files, opts)
+	if err == nil || report.Failed != 1 || !strings.Contains(report.Documents[0].Err.Error(), "--hierarchical") {
+		t.Fatalf("Run() over the limit = %v, %+v", err, report)
+	}
+
+	opts.Hierarchical = true
+	report, err = Run(context.Background(), stubProvider{}, files, opts)
+	if err != nil || report.Documents[0].Result != "Result for document." {
+		t.Errorf("Run() in hierarchical mode = %v, %+v", err, report)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	files := []string{"pulp://samples/launch-memo.md", "pulp://samples/missing.md", "pulp://samples/meeting-notes.md"}
+	plan, failed := Plan(context.Background(), files, Options{Instruction: "summarize"})
+	if len(failed) != 1 || failed[0].Path != files[1] {
+		t.Errorf("Plan() failed = %+v", failed)
+	}
+
+	stages := make(map[string]int)
+	for _, c := range plan.Calls {
+		stages[c.Stage]++
+	}
+	if stages["write"] != 2 || stages["roll-up"] != 1 || stages["extraction"] < 2 {
+		t.Errorf("Plan() stages = %v", stages)
+	}
+}
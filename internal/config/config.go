@@ -2,8 +2,10 @@ package config
 
 import (
 	"errors"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,7 +16,208 @@ type Config struct {
 	Model    string `yaml:"model"`
 	BaseURL  string `yaml:"base_url,omitempty"`
 
-	Local *LocalConfig `yaml:"local,omitempty"`
+	// UI language (e.g. "de"); empty uses LANG
+	Language string `yaml:"language,omitempty"`
+
+	Local      *LocalConfig      `yaml:"local,omitempty"`
+	Serve      *ServeConfig      `yaml:"serve,omitempty"`
+	Limits     *LimitsConfig     `yaml:"limits,omitempty"`
+	Generation *GenerationConfig `yaml:"generation,omitempty"`
+
+	Transcription *TranscriptionConfig `yaml:"transcription,omitempty"`
+
+	// About the user and their preferences ("My name is Ana, I work at
+	// Acme, prefer UK English"), prepended to chat and writer system
+	// prompts. Not used by serve.
+	Preamble string `yaml:"preamble,omitempty"`
+
+	// Language of extracted key points and summaries: "document" keeps
+	// the source language, a name ("German") translates to it, empty
+	// leaves it to the model
+	ExtractionLanguage string `yaml:"extraction_language,omitempty"`
+
+	// Maximum requests in flight per provider name, e.g. {ollama: 1}
+	MaxInFlight map[string]int `yaml:"max_in_flight,omitempty"`
+
+	// Named destinations for results, and the one [s] in the result
+	// view sends to (empty saves a file in ~/Documents)
+	Outputs       map[string]OutputConfig `yaml:"outputs,omitempty"`
+	DefaultOutput string                  `yaml:"default_output,omitempty"`
+}
+
+// OutputConfig is a destination for results, see internal/output
+type OutputConfig struct {
+	// file, clipboard, stdout, webhook or command
+	Type string `yaml:"type"`
+
+	// file: a file, or a directory to save results into by title
+	Path string `yaml:"path,omitempty"`
+
+	// webhook: URL, body format (json, slack or text) and extra headers;
+	// $VARS in header values are expanded from the environment
+	URL     string            `yaml:"url,omitempty"`
+	Format  string            `yaml:"format,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// command: program that reads the result on stdin; {title} in args
+	// is substituted
+	Command string   `yaml:"command,omitempty"`
+	Args    []string `yaml:"args,omitempty"`
+}
+
+// Default requests in flight: local models serve one or two requests
+// at a time, cloud APIs handle more before rate limiting
+const (
+	DefaultLocalInFlight = 2
+	DefaultCloudInFlight = 8
+)
+
+// InFlightLimit returns the maximum concurrent requests for a provider
+func (c *Config) InFlightLimit(provider string) int {
+	if n := c.MaxInFlight[provider]; n > 0 {
+		return n
+	}
+	if provider == "ollama" {
+		return DefaultLocalInFlight
+	}
+	return DefaultCloudInFlight
+}
+
+// TranscriptionConfig sets how audio files are turned into text before
+// processing: a local Whisper binary by default, or an OpenAI-compatible
+// transcription API when api_url is set
+type TranscriptionConfig struct {
+	// Local binary (whisper, whisper-cli) and its arguments; {input},
+	// {model} and {output_dir} are substituted
+	Command string   `yaml:"command,omitempty"`
+	Args    []string `yaml:"args,omitempty"`
+
+	// Whisper model name, or a ggml model file for whisper.cpp
+	Model string `yaml:"model,omitempty"`
+
+	// e.g. https://api.openai.com/v1
+	APIURL string `yaml:"api_url,omitempty"`
+	APIKey string `yaml:"api_key,omitempty"`
+}
+
+// Default transcription models
+const (
+	DefaultWhisperModel    = "base"
+	DefaultTranscribeModel = "whisper-1"
+)
+
+// TranscriptionSettings returns the transcription config, filling in
+// defaults and expanding ~ in the command and model paths. The API gets
+// only its own key, except that an OpenAI provider key is reused for
+// OpenAI's own API; other hosts never see the provider key.
+func (c *Config) TranscriptionSettings() TranscriptionConfig {
+	var s TranscriptionConfig
+	if c.Transcription != nil {
+		s = *c.Transcription
+	}
+	s.Command = expandHome(s.Command)
+	s.Model = expandHome(s.Model)
+	if s.APIURL != "" {
+		if s.Model == "" {
+			s.Model = DefaultTranscribeModel
+		}
+		if s.APIKey == "" && c.Provider == "openai" && isOpenAIURL(s.APIURL) {
+			s.APIKey = c.APIKey
+		}
+	} else if s.Model == "" {
+		s.Model = DefaultWhisperModel
+	}
+	return s
+}
+
+// isOpenAIURL reports whether rawURL is OpenAI's API over HTTPS
+func isOpenAIURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && u.Scheme == "https" && u.Hostname() == "api.openai.com"
+}
+
+// GenerationConfig tunes the final write and chat responses.
+// Extraction and skill matching keep their own fixed settings.
+type GenerationConfig struct {
+	Temperature *float64 `yaml:"temperature,omitempty"`
+	MaxTokens   int      `yaml:"max_tokens,omitempty"`
+	Stream      *bool    `yaml:"stream,omitempty"`
+}
+
+// GenerationSettings are the generation parameters with defaults applied
+type GenerationSettings struct {
+	Temperature float64
+	MaxTokens   int
+	Stream      bool
+}
+
+// Default generation parameters
+const (
+	DefaultTemperature = 0.7
+	DefaultMaxTokens   = 4096
+)
+
+// GenerationSettings returns the generation parameters, filling in defaults
+func (c *Config) GenerationSettings() GenerationSettings {
+	s := GenerationSettings{
+		Temperature: DefaultTemperature,
+		MaxTokens:   DefaultMaxTokens,
+		Stream:      true,
+	}
+	if g := c.Generation; g != nil {
+		if g.Temperature != nil {
+			s.Temperature = *g.Temperature
+		}
+		if g.MaxTokens > 0 {
+			s.MaxTokens = g.MaxTokens
+		}
+		if g.Stream != nil {
+			s.Stream = *g.Stream
+		}
+	}
+	return s
+}
+
+// SetGenerationSettings stores the generation parameters in the config
+func (c *Config) SetGenerationSettings(s GenerationSettings) {
+	c.Generation = &GenerationConfig{
+		Temperature: &s.Temperature,
+		MaxTokens:   s.MaxTokens,
+		Stream:      &s.Stream,
+	}
+}
+
+// LimitsConfig sets the size above which documents need confirmation
+// before processing
+type LimitsConfig struct {
+	MaxWords int `yaml:"max_words,omitempty"`
+	MaxPages int `yaml:"max_pages,omitempty"`
+}
+
+// Default document size thresholds
+const (
+	DefaultMaxWords = 40000
+	DefaultMaxPages = 150
+)
+
+// Exceeds reports whether a document of this size is over the limits;
+// zero limits are unlimited
+func (l LimitsConfig) Exceeds(words, pages int) bool {
+	return (l.MaxWords > 0 && words > l.MaxWords) || (l.MaxPages > 0 && pages > l.MaxPages)
+}
+
+// DocLimits returns the size thresholds, filling in defaults
+func (c *Config) DocLimits() LimitsConfig {
+	limits := LimitsConfig{MaxWords: DefaultMaxWords, MaxPages: DefaultMaxPages}
+	if c.Limits != nil {
+		if c.Limits.MaxWords > 0 {
+			limits.MaxWords = c.Limits.MaxWords
+		}
+		if c.Limits.MaxPages > 0 {
+			limits.MaxPages = c.Limits.MaxPages
+		}
+	}
+	return limits
 }
 
 type LocalConfig struct {
@@ -24,6 +227,30 @@ type LocalConfig struct {
 	Model    string `yaml:"model"`
 }
 
+// ServeConfig configures `pulp serve` for shared team hosts
+type ServeConfig struct {
+	Addr string `yaml:"addr,omitempty"`
+
+	// Default requests per minute for tokens without their own limit
+	RateLimit int `yaml:"rate_limit,omitempty"`
+
+	// Seconds a /v1/process request may run before its provider calls
+	// are canceled; 0 uses DefaultRequestTimeout
+	RequestTimeout int `yaml:"request_timeout,omitempty"`
+
+	Tokens []ServeToken `yaml:"tokens,omitempty"`
+}
+
+// DefaultRequestTimeout is how long a serve request may run, in seconds
+const DefaultRequestTimeout = 300
+
+// ServeToken is an API key for one user of a shared serve host
+type ServeToken struct {
+	Name      string `yaml:"name"`
+	Token     string `yaml:"token"`
+	RateLimit int    `yaml:"rate_limit,omitempty"` // Requests per minute
+}
+
 func DefaultConfig() *Config {
 	return &Config{
 		Provider: "ollama",
@@ -37,6 +264,16 @@ func DefaultConfig() *Config {
 	}
 }
 
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
 func ConfigDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -0,0 +1,219 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sant0-9/pulp/internal/config"
+)
+
+// AudioExtensions are the voice note formats transcribed before processing
+var AudioExtensions = []string{".mp3", ".m4a", ".wav"}
+
+// IsAudio reports whether path is an audio file Pulp can transcribe
+func IsAudio(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range AudioExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Transcriber turns audio into text with a local Whisper binary or an
+// OpenAI-compatible transcription API
+type Transcriber struct {
+	cfg        config.TranscriptionConfig
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// NewTranscriber creates a transcriber from settings with defaults
+// applied (see config.TranscriptionSettings)
+func NewTranscriber(cfg config.TranscriptionConfig) *Transcriber {
+	return &Transcriber{
+		cfg:        cfg,
+		httpClient: &http.Client{},
+		timeout:    15 * time.Minute,
+	}
+}
+
+// Transcribe returns the transcript of the audio file at path
+func (t *Transcriber) Transcribe(ctx context.Context, path string) (string, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "", fmt.Errorf("file not found: %s", path)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	var text string
+	var err error
+	if t.cfg.APIURL != "" {
+		text, err = t.transcribeAPI(ctx, path)
+	} else {
+		text, err = t.transcribeLocal(ctx, path)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("no speech found in %s", filepath.Base(path))
+	}
+	return text, nil
+}
+
+// Known local binaries: openai-whisper, then whisper.cpp
+var whisperBinaries = []string{"whisper", "whisper-cli", "whisper-cpp"}
+
+func (t *Transcriber) transcribeLocal(ctx context.Context, path string) (string, error) {
+	command := t.cfg.Command
+	if command == "" {
+		for _, name := range whisperBinaries {
+			if _, err := exec.LookPath(name); err == nil {
+				command = name
+				break
+			}
+		}
+		if command == "" {
+			return "", fmt.Errorf("no whisper binary found in PATH; install whisper or set transcription.api_url")
+		}
+	}
+
+	// whisper.cpp loads a ggml file, not a model name like "base"
+	if isWhisperCpp(command) {
+		if info, err := os.Stat(t.cfg.Model); err != nil || info.IsDir() {
+			return "", fmt.Errorf("%s needs a ggml model file: set transcription.model to its path (e.g. ~/models/ggml-base.en.bin), not %q", filepath.Base(command), t.cfg.Model)
+		}
+	}
+
+	args := t.cfg.Args
+	if len(args) == 0 {
+		args = defaultWhisperArgs(command)
+	}
+
+	outDir, err := os.MkdirTemp("", "pulp-transcript-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(outDir)
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	replacer := strings.NewReplacer("{input}", absPath, "{model}", t.cfg.Model, "{output_dir}", outDir)
+	expanded := make([]string, len(args))
+	for i, a := range args {
+		expanded[i] = replacer.Replace(a)
+	}
+
+	cmd := exec.CommandContext(ctx, command, expanded...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("transcription failed: %s", msg)
+	}
+
+	// Both whisper flavours write a .txt transcript into the output dir
+	matches, _ := filepath.Glob(filepath.Join(outDir, "*.txt"))
+	if len(matches) == 0 {
+		return "", fmt.Errorf("transcription failed: %s wrote no transcript", command)
+	}
+	data, err := os.ReadFile(matches[0])
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// isWhisperCpp reports whether command is a whisper.cpp binary
+func isWhisperCpp(command string) bool {
+	base := filepath.Base(command)
+	return strings.Contains(base, "cli") || strings.Contains(base, "cpp")
+}
+
+// defaultWhisperArgs returns the arguments for a known whisper binary
+func defaultWhisperArgs(command string) []string {
+	if isWhisperCpp(command) {
+		// whisper.cpp: model is a ggml file
+		return []string{"-m", "{model}", "-f", "{input}", "-otxt", "-of", "{output_dir}/transcript"}
+	}
+	return []string{"{input}", "--model", "{model}", "--output_format", "txt", "--output_dir", "{output_dir}"}
+}
+
+// transcribeAPI sends the file to an OpenAI-compatible transcription
+// API. Local servers often take no key, so one is only sent if set.
+func (t *Transcriber) transcribeAPI(ctx context.Context, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return "", err
+	}
+	w.WriteField("model", t.cfg.Model)
+	w.WriteField("response_format", "text")
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	url := strings.TrimSuffix(t.cfg.APIURL, "/") + "/audio/transcriptions"
+	req, err := http.NewRequestWithContext(ctx, "POST", url, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	if t.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.cfg.APIKey)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("cannot connect to transcription API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("transcription API error: status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return string(data), nil
+}
+
+// FromTranscript builds a document from an audio transcript
+func FromTranscript(transcript, source string) *Document {
+	doc := FromMarkdown(transcript, source)
+	doc.Metadata.SourceFormat = strings.TrimPrefix(strings.ToLower(filepath.Ext(source)), ".")
+	if info, err := os.Stat(source); err == nil {
+		doc.Metadata.FileSizeBytes = info.Size()
+	}
+	return doc
+}
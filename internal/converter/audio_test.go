@@ -0,0 +1,127 @@
+package converter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/config"
+)
+
+func TestLoadAudioViaAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/transcriptions" || r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		f, _, err := r.FormFile("file")
+		if err != nil || r.FormValue("model") != "whisper-1" {
+			http.Error(w, "missing file or model", http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(f)
+		io.WriteString(w, "Transcript of "+string(data)+".\n")
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "memo.m4a")
+	if err := os.WriteFile(path, []byte("audio"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		Transcription: &config.TranscriptionConfig{APIURL: srv.URL + "/v1", APIKey: "sk-test"},
+	}
+	doc, err := Load(context.Background(), path, LoadOptions{Transcription: cfg.TranscriptionSettings()})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if doc.Content != "Transcript of audio." {
+		t.Errorf("content = %q", doc.Content)
+	}
+	if doc.Metadata.Title != "memo" || doc.Metadata.SourceFormat != "m4a" || doc.Metadata.FileSizeBytes != 5 {
+		t.Errorf("metadata = %+v", doc.Metadata)
+	}
+}
+
+func TestTranscriptionKeyStaysWithItsAPI(t *testing.T) {
+	cfg := &config.Config{
+		Provider:      "anthropic",
+		APIKey:        "sk-ant-secret",
+		Transcription: &config.TranscriptionConfig{APIURL: "https://transcribe.example.com/v1"},
+	}
+	if key := cfg.TranscriptionSettings().APIKey; key != "" {
+		t.Errorf("provider key passed to another host: %q", key)
+	}
+
+	cfg.Provider = "openai"
+	cfg.Transcription.APIURL = "https://api.openai.com/v1"
+	if key := cfg.TranscriptionSettings().APIKey; key != "sk-ant-secret" {
+		t.Errorf("OpenAI key not reused for OpenAI's API: %q", key)
+	}
+}
+
+func TestTranscriptionWithoutKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			http.Error(w, "unexpected Authorization: "+auth, http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, "Local transcript.")
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "memo.wav")
+	if err := os.WriteFile(path, []byte("audio"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{Transcription: &config.TranscriptionConfig{APIURL: srv.URL + "/v1"}}
+	text, err := NewTranscriber(cfg.TranscriptionSettings()).Transcribe(context.Background(), path)
+	if err != nil || text != "Local transcript." {
+		t.Errorf("Transcribe() without a key = %q, %v", text, err)
+	}
+}
+
+func TestWhisperCppNeedsModelFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memo.wav")
+	if err := os.WriteFile(path, []byte("audio"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{Transcription: &config.TranscriptionConfig{Command: "/usr/local/bin/whisper-cli"}}
+	_, err := NewTranscriber(cfg.TranscriptionSettings()).Transcribe(context.Background(), path)
+	if err == nil || !strings.Contains(err.Error(), "set transcription.model") {
+		t.Errorf("Transcribe() with model %q = %v", cfg.TranscriptionSettings().Model, err)
+	}
+
+	// ~ is the home directory, as in the README example
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, "ggml-base.en.bin"), []byte("model"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Transcription.Model = "~/ggml-base.en.bin"
+	_, err = NewTranscriber(cfg.TranscriptionSettings()).Transcribe(context.Background(), path)
+	if err == nil || strings.Contains(err.Error(), "set transcription.model") {
+		t.Errorf("Transcribe() with model %q = %v", cfg.Transcription.Model, err)
+	}
+}
+
+func TestIsAudio(t *testing.T) {
+	for path, want := range map[string]bool{
+		"memo.MP3":   true,
+		"a/b.wav":    true,
+		"report.pdf": false,
+		"notes.md":   false,
+	} {
+		if got := IsAudio(path); got != want {
+			t.Errorf("IsAudio(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package converter
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/samples"
+)
+
+// LoadOptions configures conversions that need more than the path
+type LoadOptions struct {
+	// Used for audio files (see config.TranscriptionSettings)
+	Transcription config.TranscriptionConfig
+}
+
+// Load converts the document at path. Bundled samples
+// (pulp://samples/...) are already markdown and skip the Docling bridge;
+// audio files are transcribed and newsletters are cleaned up in Go.
+func Load(ctx context.Context, path string, opts LoadOptions) (*Document, error) {
+	if samples.IsSample(path) {
+		data, err := samples.Read(path)
+		if err != nil {
+			return nil, err
+		}
+		if IsNewsletter(path, data) {
+			return FromNewsletter(data, path)
+		}
+		return FromMarkdown(string(data), path), nil
+	}
+
+	if IsAudio(path) {
+		transcript, err := NewTranscriber(opts.Transcription).Transcribe(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		return FromTranscript(transcript, path), nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".eml", ".html", ".htm":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if IsNewsletter(path, data) {
+			return FromNewsletter(data, path)
+		}
+	}
+
+	c, err := NewConverter()
+	if err != nil {
+		return nil, err
+	}
+	return c.Convert(ctx, path)
+}
+
+// FromMarkdown builds a document from markdown that needs no conversion.
+// The title comes from the first top-level heading.
+func FromMarkdown(content, source string) *Document {
+	content = strings.TrimSpace(content)
+
+	title := strings.TrimSuffix(path.Base(source), path.Ext(source))
+	for _, line := range strings.Split(content, "\n") {
+		if h, ok := strings.CutPrefix(line, "# "); ok {
+			title = strings.TrimSpace(h)
+			break
+		}
+	}
+
+	// Match the bridge's preview: first 500 characters
+	preview := []rune(content)
+	if len(preview) > 500 {
+		preview = append([]rune(strings.TrimSpace(string(preview[:500]))), []rune("...")...)
+	}
+
+	return &Document{
+		Content: content,
+		Preview: string(preview),
+		Metadata: Metadata{
+			Title:         title,
+			SourcePath:    source,
+			SourceFormat:  "md",
+			FileSizeBytes: int64(len(content)),
+			WordCount:     len(strings.Fields(content)),
+			ConvertedAt:   time.Now(),
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/samples"
+)
+
+func TestLoadSamples(t *testing.T) {
+	uris := samples.List()
+	if len(uris) == 0 {
+		t.Fatal("no bundled samples")
+	}
+
+	for _, uri := range uris {
+		doc, err := Load(context.Background(), uri, LoadOptions{})
+		if err != nil {
+			t.Fatalf("Load(%s) error: %v", uri, err)
+		}
+		if doc.Metadata.Title == "" || doc.Metadata.WordCount == 0 {
+			t.Errorf("Load(%s) metadata = %+v", uri, doc.Metadata)
+		}
+	}
+
+	doc, err := Load(context.Background(), "pulp://samples/quarterly-report.md", LoadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Metadata.Title != "Northwind Analytics Q3 Quarterly Report" {
+		t.Errorf("title = %q", doc.Metadata.Title)
+	}
+
+	for _, bad := range []string{"pulp://samples/missing.md", "pulp://samples/../samples.go"} {
+		if _, err := Load(context.Background(), bad, LoadOptions{}); err == nil {
+			t.Errorf("Load(%s) succeeded, want error", bad)
+		}
+	}
+}
@@ -0,0 +1,410 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IsNewsletter reports whether an HTML file looks like an email or
+// newsletter rather than a web page. .eml files always are.
+func IsNewsletter(path string, data []byte) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".eml":
+		return true
+	case ".html", ".htm":
+	default:
+		return false
+	}
+
+	lower := bytes.ToLower(data)
+	for _, marker := range []string{"unsubscribe", "view this email in your browser", "view in browser", `role="presentation"`, "<!--[if mso"} {
+		if bytes.Contains(lower, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromNewsletter converts an HTML newsletter (or an .eml message) to
+// markdown without the email boilerplate: tracking pixels, hidden preview
+// text, tracking parameters on links and unsubscribe footers are removed,
+// and links are kept as markdown links.
+func FromNewsletter(data []byte, source string) (*Document, error) {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(source)), ".")
+
+	var subject string
+	page := string(data)
+	if format == "eml" {
+		var err error
+		var isHTML bool
+		subject, page, isHTML, err = readEmail(data)
+		if err != nil {
+			return nil, err
+		}
+		if !isHTML {
+			doc := FromMarkdown(page, source)
+			setNewsletterMetadata(doc, subject, format, len(data))
+			return doc, nil
+		}
+	}
+
+	title, md := newsletterMarkdown(page)
+	if subject != "" {
+		title = subject
+	}
+	if strings.TrimSpace(md) == "" {
+		return nil, fmt.Errorf("no readable content in %s", filepath.Base(source))
+	}
+
+	doc := FromMarkdown(md, source)
+	setNewsletterMetadata(doc, title, format, len(data))
+	return doc, nil
+}
+
+func setNewsletterMetadata(doc *Document, title, format string, size int) {
+	if title != "" {
+		doc.Metadata.Title = title
+	}
+	doc.Metadata.SourceFormat = format
+	doc.Metadata.FileSizeBytes = int64(size)
+}
+
+// Elements dropped with their content before parsing. Scripts and styles
+// also hold text the lenient XML decoder can't tokenize.
+var newsletterStrip = []*regexp.Regexp{
+	regexp.MustCompile(`(?is)<script\b.*?</script\s*>`),
+	regexp.MustCompile(`(?is)<style\b.*?</style\s*>`),
+	regexp.MustCompile(`(?is)<noscript\b.*?</noscript\s*>`),
+	regexp.MustCompile(`(?is)<head\b.*?</head\s*>`),
+	regexp.MustCompile(`(?is)<svg\b.*?</svg\s*>`),
+}
+
+var titlePattern = regexp.MustCompile(`(?is)<title\b[^>]*>(.*?)</title\s*>`)
+
+// Links and lines that are email boilerplate rather than content
+var newsletterJunk = regexp.MustCompile(`(?i)unsubscribe|manage (your )?(email )?(preferences|subscription)|view (this email )?(it )?in (your |a )?browser|forward (this )?to a friend|email preferences`)
+
+// Boilerplate that also turns up in real writing ("readers can opt
+// out"), so it's only dropped from the footer
+var footerJunk = regexp.MustCompile(`(?i)update (your )?preferences|you('re| are) receiving this|opt[ -]out`)
+
+// Invisible characters newsletters use to pad the preview text
+var invisibleChars = strings.NewReplacer(
+	"\u200b", "", "\u200c", "", "\u200d", "", "\u034f", "", "\ufeff", "", "\u00ad", "", "\u00a0", " ",
+)
+
+var (
+	spaceRun = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankRun = regexp.MustCompile(`\n{3,}`)
+)
+
+// newsletterMarkdown converts newsletter HTML to markdown and returns its
+// <title> alongside
+func newsletterMarkdown(page string) (string, string) {
+	var title string
+	if m := titlePattern.FindStringSubmatch(page); m != nil {
+		title = strings.TrimSpace(spaceRun.ReplaceAllString(invisibleChars.Replace(html.UnescapeString(m[1])), " "))
+	}
+	for _, re := range newsletterStrip {
+		page = re.ReplaceAllString(page, "")
+	}
+
+	d := xml.NewDecoder(strings.NewReader(page))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	var out strings.Builder
+	var link *strings.Builder // Text of the <a> being read
+	var href string
+	skip := 0 // Depth inside a hidden element
+
+	write := func(s string) {
+		if link != nil {
+			link.WriteString(s)
+		} else {
+			out.WriteString(s)
+		}
+	}
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			// io.EOF, or markup too broken to continue: keep what we have
+			break
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if skip > 0 {
+				if !isVoid(name) {
+					skip++
+				}
+				continue
+			}
+			if isHidden(t) {
+				if !isVoid(name) {
+					skip = 1
+				}
+				continue
+			}
+
+			switch name {
+			case "a":
+				link = &strings.Builder{}
+				href = attr(t, "href")
+			case "img":
+				if alt := strings.TrimSpace(attr(t, "alt")); alt != "" && !isTrackingPixel(t) {
+					write(" " + alt + " ")
+				}
+			case "br":
+				write("\n")
+			case "li":
+				write("\n- ")
+			case "td", "th":
+				write(" ")
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				write("\n\n" + strings.Repeat("#", int(name[1]-'0')) + " ")
+			default:
+				if isBlock(name) {
+					write("\n\n")
+				}
+			}
+
+		case xml.EndElement:
+			name := strings.ToLower(t.Name.Local)
+			if skip > 0 {
+				if !isVoid(name) {
+					skip--
+				}
+				continue
+			}
+
+			switch {
+			case name == "a" && link != nil:
+				text := strings.TrimSpace(spaceRun.ReplaceAllString(strings.ReplaceAll(link.String(), "\n", " "), " "))
+				link = nil
+				out.WriteString(formatLink(text, href))
+			case isBlock(name) || strings.HasPrefix(name, "h") && len(name) == 2:
+				write("\n\n")
+			}
+
+		case xml.CharData:
+			if skip == 0 {
+				write(spaceRun.ReplaceAllString(strings.ReplaceAll(invisibleChars.Replace(string(t)), "\n", " "), " "))
+			}
+		}
+	}
+
+	// Tidy lines and drop boilerplate left outside links
+	lines := strings.Split(out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaceRun.ReplaceAllString(line, " "))
+	}
+	footer := footerStart(lines)
+	for i, line := range lines {
+		junk := newsletterJunk.MatchString(line) || (i >= footer && footerJunk.MatchString(line))
+		if strings.Trim(line, "-|•· ") == "" || (len(line) < 200 && junk) {
+			lines[i] = ""
+		}
+	}
+	md := blankRun.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return title, strings.TrimSpace(md)
+}
+
+// footerStart returns the index of the first line after the last
+// substantive paragraph: one of eight or more words that isn't
+// boilerplate. Everything from there on is the email's footer.
+func footerStart(lines []string) int {
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := lines[i]
+		if len(strings.Fields(line)) >= 8 && !newsletterJunk.MatchString(line) && !footerJunk.MatchString(line) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// formatLink renders a link as markdown, dropping boilerplate links and
+// tracking parameters
+func formatLink(text, href string) string {
+	if text == "" || newsletterJunk.MatchString(text) || strings.Contains(strings.ToLower(href), "unsubscribe") {
+		return ""
+	}
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, cleanURL(href))
+}
+
+// Query parameters added by mail platforms to track clicks
+var trackingParams = map[string]bool{
+	"mc_cid": true, "mc_eid": true, "fbclid": true, "gclid": true, "_hsenc": true,
+	"_hsmi": true, "mkt_tok": true, "vero_id": true, "ck_subscriber_id": true, "oly_enc_id": true,
+}
+
+// cleanURL removes tracking parameters from a link
+func cleanURL(href string) string {
+	u, err := url.Parse(href)
+	if err != nil || u.RawQuery == "" {
+		return href
+	}
+
+	q := u.Query()
+	for key := range q {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingParams[strings.ToLower(key)] {
+			q.Del(key)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func attr(t xml.StartElement, name string) string {
+	for _, a := range t.Attr {
+		if strings.EqualFold(a.Name.Local, name) {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// isHidden reports whether an element is invisible in mail clients,
+// like the preview text shown only in the inbox list
+func isHidden(t xml.StartElement) bool {
+	if strings.EqualFold(t.Name.Local, "img") && isTrackingPixel(t) {
+		return true
+	}
+	for _, a := range t.Attr {
+		if strings.EqualFold(a.Name.Local, "hidden") {
+			return true
+		}
+	}
+	style := strings.ToLower(strings.ReplaceAll(attr(t, "style"), " ", ""))
+	for _, s := range []string{"display:none", "mso-hide:all", "visibility:hidden", "max-height:0;", "max-height:0px"} {
+		if strings.Contains(style+";", s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrackingPixel reports whether an image is a 1x1 open tracker
+func isTrackingPixel(t xml.StartElement) bool {
+	w, h := attr(t, "width"), attr(t, "height")
+	tiny := func(v string) bool {
+		v = strings.TrimSuffix(strings.TrimSpace(v), "px")
+		return v == "0" || v == "1"
+	}
+	return tiny(w) || tiny(h)
+}
+
+func isVoid(name string) bool {
+	switch name {
+	case "img", "br", "hr", "meta", "link", "input", "area", "base", "col", "wbr", "source":
+		return true
+	}
+	return false
+}
+
+func isBlock(name string) bool {
+	switch name {
+	case "p", "div", "table", "tr", "section", "article", "header", "footer", "center", "blockquote", "ul", "ol", "hr":
+		return true
+	}
+	return false
+}
+
+// readEmail returns the subject and body of an .eml message, preferring
+// the HTML part. isHTML is false when the message is plain text only.
+func readEmail(data []byte) (subject, body string, isHTML bool, err error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return "", "", false, fmt.Errorf("not a valid email: %w", err)
+	}
+
+	dec := new(mime.WordDecoder)
+	subject, err = dec.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	htmlPart, text, err := emailParts(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return "", "", false, err
+	}
+	if htmlPart != "" {
+		return subject, htmlPart, true, nil
+	}
+	if text != "" {
+		return subject, text, false, nil
+	}
+	return "", "", false, fmt.Errorf("email has no text or HTML body")
+}
+
+// emailParts walks a MIME body and returns its first HTML and plain
+// text parts
+func emailParts(contentType, encoding string, r io.Reader) (htmlPart, text string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(r, params["boundary"])
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", "", err
+			}
+			h, t, err := emailParts(p.Header.Get("Content-Type"), p.Header.Get("Content-Transfer-Encoding"), p)
+			if err != nil {
+				return "", "", err
+			}
+			if htmlPart == "" {
+				htmlPart = h
+			}
+			if text == "" {
+				text = t
+			}
+		}
+		return htmlPart, text, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		r = quotedprintable.NewReader(r)
+	case "base64":
+		r = base64.NewDecoder(base64.StdEncoding, r)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch mediaType {
+	case "text/html":
+		return string(data), "", nil
+	case "text/plain":
+		return "", string(data), nil
+	}
+	return "", "", nil
+}
@@ -0,0 +1,76 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewsletterMarkdown(t *testing.T) {
+	html := `<html><head><title>Weekly &amp; News</title><style>p { color: red }</style></head><body>
+<span style="display: none">Preview text &#8204;&nbsp;&#8204;</span>
+<table role="presentation"><tr><td>
+<h2>Launch</h2>
+<p>Version 2 ships <b>today</b>. <a href="https://example.com/v2?utm_source=mail&amp;id=7">Release notes</a></p>
+</td></tr></table>
+<p><a href="https://example.com/unsubscribe?u=1">Unsubscribe</a> | <a href="https://example.com/prefs">Manage preferences</a></p>
+<img src="https://t.example.com/o.gif" width="1" height="1">
+</body></html>`
+
+	if !IsNewsletter("digest.html", []byte(html)) {
+		t.Error("IsNewsletter() = false for a table-layout email")
+	}
+
+	title, md := newsletterMarkdown(html)
+	if title != "Weekly & News" {
+		t.Errorf("title = %q", title)
+	}
+	want := "## Launch\n\nVersion 2 ships today. [Release notes](https://example.com/v2?id=7)"
+	if md != want {
+		t.Errorf("markdown =\n%s\nwant\n%s", md, want)
+	}
+	for _, junk := range []string{"Preview", "nsubscribe", "preferences", "o.gif", "color"} {
+		if strings.Contains(md, junk) {
+			t.Errorf("markdown kept %q", junk)
+		}
+	}
+}
+
+func TestFromNewsletterEmail(t *testing.T) {
+	eml := "Subject: =?UTF-8?Q?Q4_update_=E2=80=93_pricing?=\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/alternative; boundary=b1\r\n\r\n" +
+		"--b1\r\nContent-Type: text/plain\r\n\r\nplain version\r\n" +
+		"--b1\r\nContent-Type: text/html\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n" +
+		"<p>Prices change on <a href=3D\"https://example.com/p?mc_cid=3D1\">Jan 1</a>.</p>\r\n" +
+		"--b1--\r\n"
+
+	doc, err := FromNewsletter([]byte(eml), "update.eml")
+	if err != nil {
+		t.Fatalf("FromNewsletter() error = %v", err)
+	}
+	if doc.Metadata.Title != "Q4 update – pricing" || doc.Metadata.SourceFormat != "eml" {
+		t.Errorf("metadata = %+v", doc.Metadata)
+	}
+	if doc.Content != "Prices change on [Jan 1](https://example.com/p)." {
+		t.Errorf("content = %q", doc.Content)
+	}
+}
+
+func TestNewsletterKeepsOptOutInContent(t *testing.T) {
+	html := `<html><body>
+<h2>Privacy</h2>
+<p>Most readers opt out of ad tracking when the choice is clear, the survey found.</p>
+<p>Next week we look at how browsers handle third-party cookies by default.</p>
+<p>You are receiving this because you subscribed. <a href="https://example.com/prefs">Update your preferences</a> or <a href="https://example.com/out">opt out</a>.</p>
+</body></html>`
+
+	_, md := newsletterMarkdown(html)
+	if !strings.Contains(md, "Most readers opt out of ad tracking") {
+		t.Errorf("markdown dropped content mentioning opt out:\n%s", md)
+	}
+	for _, junk := range []string{"receiving this", "preferences", "example.com/out"} {
+		if strings.Contains(md, junk) {
+			t.Errorf("markdown kept footer %q:\n%s", junk, md)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Package dryrun plans a full document run (skill matching, extraction
+// and writing) without sending anything to the LLM provider.
+package dryrun
+
+import (
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/intent"
+	"github.com/sant0-9/pulp/internal/pipeline"
+	"github.com/sant0-9/pulp/internal/skill"
+	"github.com/sant0-9/pulp/internal/writer"
+)
+
+// Options controls how the run would be made
+type Options struct {
+	Model        string
+	Instruction  string
+	SkillIndex   *skill.SkillIndex
+	Hierarchical bool
+	Language     string // Extraction language, see pipeline.SetLanguage
+	Preamble     string // About the user, see config.Preamble
+
+	// Generation overrides the writer's temperature and token limit
+	Generation *config.GenerationSettings
+}
+
+// Build returns every request the run would send, in order
+func Build(doc *converter.Document, opts Options) *pipeline.Plan {
+	parser := intent.NewParser(nil, opts.Model, opts.SkillIndex)
+	parsed, matchReq := parser.Plan(opts.Instruction)
+
+	pipe := pipeline.NewPipeline(nil, opts.Model)
+	pipe.SetHierarchical(opts.Hierarchical)
+	pipe.SetLanguage(opts.Language)
+	if parsed.ExtractionLanguage != "" {
+		pipe.SetLanguage(parsed.ExtractionLanguage)
+	}
+	extraction := pipe.Plan(doc)
+
+	plan := &pipeline.Plan{
+		DocTitle:  extraction.DocTitle,
+		ChunkSize: extraction.ChunkSize,
+		Chunks:    extraction.Chunks,
+	}
+	if matchReq != nil {
+		plan.AddCall("skill match", "choose a skill for the instruction", matchReq)
+	}
+	plan.Calls = append(plan.Calls, extraction.Calls...)
+
+	// The writer prompt embeds extraction output, shown as a placeholder
+	plan.AddCall("write", "final output", newWriter(opts).Request(&writer.WriteRequest{
+		Intent:   parsed,
+		DocTitle: doc.Metadata.Title,
+	}))
+
+	return plan
+}
+
+// BuildFollowUp returns every request a follow-up on a finished result
+// would send. Follow-ups reuse the document's extraction, so that's skill
+// matching and the revision; revision builds the writer's request for
+// the parsed intent.
+func BuildFollowUp(opts Options, revision func(*intent.Intent) *writer.WriteRequest) *pipeline.Plan {
+	parser := intent.NewParser(nil, opts.Model, opts.SkillIndex)
+	parsed, matchReq := parser.Plan(opts.Instruction)
+
+	req := revision(parsed)
+	plan := &pipeline.Plan{DocTitle: req.DocTitle}
+	if matchReq != nil {
+		plan.AddCall("skill match", "choose a skill for the instruction", matchReq)
+	}
+	plan.AddCall("write", "revision", newWriter(opts).Request(req))
+
+	return plan
+}
+
+func newWriter(opts Options) *writer.Writer {
+	w := writer.NewWriter(nil, opts.Model)
+	w.SetPreamble(opts.Preamble)
+	if g := opts.Generation; g != nil {
+		w.SetParams(g.Temperature, g.MaxTokens)
+	}
+	return w
+}
@@ -0,0 +1,185 @@
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sant0-9/pulp/internal/config"
+)
+
+// DefaultLang is the built-in catalog every other language falls back to
+const DefaultLang = "en"
+
+//go:embed locales/*.yaml
+var locales embed.FS
+
+// Catalog maps message keys to UI strings for one language
+type Catalog struct {
+	lang     string
+	messages map[string]string
+}
+
+// Load builds the catalog for lang. Messages come from the bundled
+// locales, then ~/.config/pulp/locales/<lang>.yaml, so community
+// translations can be dropped in without rebuilding. Missing messages
+// fall back to English.
+func Load(lang string) *Catalog {
+	lang = normalize(lang)
+	if lang == "" {
+		lang = DefaultLang
+	}
+
+	c := &Catalog{lang: lang, messages: make(map[string]string)}
+	c.merge(readBundled(DefaultLang))
+
+	// "pt-br" also picks up a plain "pt" catalog
+	var candidates []string
+	if base, _, ok := strings.Cut(lang, "-"); ok {
+		candidates = append(candidates, base)
+	}
+	candidates = append(candidates, lang)
+
+	for _, l := range candidates {
+		if l == DefaultLang {
+			continue
+		}
+		c.merge(readBundled(l))
+	}
+	for _, l := range candidates {
+		c.merge(readUser(l))
+	}
+
+	return c
+}
+
+// Lang returns the catalog's language code
+func (c *Catalog) Lang() string {
+	return c.lang
+}
+
+// T returns the message for key, formatted with args. Unknown keys are
+// returned as-is so missing translations are easy to spot.
+func (c *Catalog) T(key string, args ...any) string {
+	msg, ok := c.messages[key]
+	if !ok {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+func (c *Catalog) merge(messages map[string]string) {
+	for k, v := range messages {
+		if v != "" {
+			c.messages[k] = v
+		}
+	}
+}
+
+var current = Load(DefaultLang)
+
+// SetLanguage switches the catalog used by T
+func SetLanguage(lang string) {
+	current = Load(lang)
+}
+
+// T looks up key in the current catalog
+func T(key string, args ...any) string {
+	return current.T(key, args...)
+}
+
+// Detect picks the UI language: the configured one if set, otherwise
+// the POSIX locale (LC_ALL, LC_MESSAGES, LANG)
+func Detect(configured string) string {
+	if configured != "" {
+		return normalize(configured)
+	}
+
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			if lang := normalize(v); lang != "" {
+				return lang
+			}
+		}
+	}
+	return DefaultLang
+}
+
+// Available lists the bundled and user-installed languages
+func Available() []string {
+	seen := map[string]bool{}
+
+	entries, _ := locales.ReadDir("locales")
+	for _, e := range entries {
+		seen[strings.TrimSuffix(e.Name(), ".yaml")] = true
+	}
+	if dir, err := userDir(); err == nil {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.yaml"))
+		for _, m := range matches {
+			seen[strings.TrimSuffix(filepath.Base(m), ".yaml")] = true
+		}
+	}
+
+	var langs []string
+	for l := range seen {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// normalize turns locale names like "de_DE.UTF-8" into "de-de".
+// "C" and "POSIX" mean no preference.
+func normalize(lang string) string {
+	lang, _, _ = strings.Cut(lang, ".")
+	lang, _, _ = strings.Cut(lang, "@")
+	lang = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+	if lang == "c" || lang == "posix" {
+		return ""
+	}
+	return lang
+}
+
+func readBundled(lang string) map[string]string {
+	data, err := locales.ReadFile("locales/" + lang + ".yaml")
+	if err != nil {
+		return nil
+	}
+	return parse(data)
+}
+
+func readUser(lang string) map[string]string {
+	dir, err := userDir()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, lang+".yaml"))
+	if err != nil {
+		return nil
+	}
+	return parse(data)
+}
+
+func parse(data []byte) map[string]string {
+	var messages map[string]string
+	if err := yaml.Unmarshal(data, &messages); err != nil {
+		return nil
+	}
+	return messages
+}
+
+func userDir() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "locales"), nil
+}
@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+
+	tests := []struct {
+		configured string
+		lang       string
+		want       string
+	}{
+		{"", "de_DE.UTF-8", "de-de"},
+		{"", "pt_BR@euro", "pt-br"},
+		{"", "C", "en"},
+		{"", "", "en"},
+		{"fr", "de_DE.UTF-8", "fr"},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("LANG", tt.lang)
+		if got := Detect(tt.configured); got != tt.want {
+			t.Errorf("Detect(%q) with LANG=%q = %q, want %q", tt.configured, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestLoadUserTranslation(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, ".config", "pulp", "locales")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := "welcome.subtitle: \"Dokumentenanalyse\"\ncommon.error: \"Fehler: %s\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "de.yaml"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Region falls back to the base language file
+	c := Load("de-at")
+	if got := c.T("welcome.subtitle"); got != "Dokumentenanalyse" {
+		t.Errorf("translated message = %q", got)
+	}
+	if got := c.T("common.error", "kaputt"); got != "Fehler: kaputt" {
+		t.Errorf("formatted message = %q", got)
+	}
+
+	// Untranslated keys fall back to English
+	if got := c.T("welcome.connecting"); got != "Connecting..." {
+		t.Errorf("fallback message = %q", got)
+	}
+	if got := c.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key = %q", got)
+	}
+}
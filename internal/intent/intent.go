@@ -1,6 +1,10 @@
 package intent
 
-import "github.com/sant0-9/pulp/internal/skill"
+import (
+	"regexp"
+
+	"github.com/sant0-9/pulp/internal/skill"
+)
 
 // Intent holds the user's instruction and matched skill
 type Intent struct {
@@ -12,13 +16,24 @@ type Intent struct {
 
 	// True if user explicitly invoked with /skill-name
 	ExplicitSkill bool
+
+	// "document" when the instruction asks to keep the document's
+	// language; overrides config.ExtractionLanguage
+	ExtractionLanguage string
 }
 
+// Instructions like "summarize in the original language"
+var keepLanguagePattern = regexp.MustCompile(`(?i)\b(in|keep)( the| its)? (original|document'?s?|source|same) language\b|\bdon'?t translate\b|\bwithout translating\b`)
+
 // New creates a new intent from a raw prompt
 func New(prompt string) *Intent {
-	return &Intent{
+	i := &Intent{
 		RawPrompt: prompt,
 	}
+	if keepLanguagePattern.MatchString(prompt) {
+		i.ExtractionLanguage = "document"
+	}
+	return i
 }
 
 // WithSkill attaches a skill to the intent
@@ -34,28 +34,8 @@ func NewParser(provider llm.Provider, model string, skillIndex *skill.SkillIndex
 // Parse wraps the instruction in an Intent with optional skill matching
 func (p *Parser) Parse(ctx context.Context, instruction string) (*Intent, error) {
 	// Check for explicit skill invocation (/skill-name)
-	if strings.HasPrefix(instruction, "/") {
-		parts := strings.SplitN(instruction, " ", 2)
-		skillName := strings.TrimPrefix(parts[0], "/")
-
-		if meta := p.skillIndex.Get(skillName); meta != nil {
-			// Load the full skill
-			s, err := skill.LoadFull(meta)
-			if err != nil {
-				// Fall back to no skill
-				return New(instruction), nil
-			}
-
-			// Extract remaining instruction after /skill-name
-			remaining := ""
-			if len(parts) > 1 {
-				remaining = strings.TrimSpace(parts[1])
-			}
-
-			intent := New(remaining)
-			intent.WithSkill(s, true)
-			return intent, nil
-		}
+	if intent, ok := ParseExplicit(instruction, p.skillIndex); ok {
+		return intent, nil
 	}
 
 	// Try semantic matching if we have skills
@@ -75,3 +55,46 @@ func (p *Parser) Parse(ctx context.Context, instruction string) (*Intent, error)
 	// No skill matched, return plain intent
 	return New(instruction), nil
 }
+
+// Plan returns the intent Parse would produce without calling the LLM,
+// and the skill matching request Parse would send (nil if none)
+func (p *Parser) Plan(instruction string) (*Intent, *llm.CompletionRequest) {
+	if intent, ok := ParseExplicit(instruction, p.skillIndex); ok {
+		return intent, nil
+	}
+	if p.matcher != nil {
+		return New(instruction), p.matcher.Request(instruction)
+	}
+	return New(instruction), nil
+}
+
+// ParseExplicit handles /skill-name invocations without any LLM call.
+// Returns false if the instruction doesn't name an installed skill.
+func ParseExplicit(instruction string, skillIndex *skill.SkillIndex) (*Intent, bool) {
+	if !strings.HasPrefix(instruction, "/") {
+		return nil, false
+	}
+
+	parts := strings.SplitN(instruction, " ", 2)
+	skillName := strings.TrimPrefix(parts[0], "/")
+
+	meta := skillIndex.Get(skillName)
+	if meta == nil {
+		return nil, false
+	}
+
+	// Load the full skill
+	s, err := skill.LoadFull(meta)
+	if err != nil {
+		// Fall back to no skill
+		return New(instruction), true
+	}
+
+	// Extract remaining instruction after /skill-name
+	remaining := ""
+	if len(parts) > 1 {
+		remaining = strings.TrimSpace(parts[1])
+	}
+
+	return New(remaining).WithSkill(s, true), true
+}
@@ -0,0 +1,154 @@
+package intent
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sant0-9/pulp/internal/config"
+)
+
+// DocType is a coarse document classification used for suggestions
+type DocType string
+
+const (
+	DocContract  DocType = "contract"
+	DocFinancial DocType = "financial"
+	DocMeeting   DocType = "meeting"
+	DocResearch  DocType = "research"
+	DocResume    DocType = "resume"
+	DocGeneral   DocType = "general"
+)
+
+// Keyword signals per type; the type with the most hits wins
+var docTypeSignals = map[DocType][]string{
+	DocContract:  {"agreement", "hereinafter", "party", "parties", "shall", "indemnif", "termination", "governing law", "liability", "whereas"},
+	DocFinancial: {"revenue", "quarter", "fiscal", "ebitda", "earnings", "net income", "balance sheet", "cash flow", "guidance", "per share"},
+	DocMeeting:   {"attendees", "action item", "agenda", "minutes", "next steps", "follow up", "standup", "discussed", "decision"},
+	DocResearch:  {"abstract", "methodology", "results", "conclusion", "references", "hypothesis", "et al", "dataset", "experiment"},
+	DocResume:    {"experience", "education", "skills", "resume", "curriculum vitae", "employment", "certifications"},
+}
+
+var suggestionsByType = map[DocType][]string{
+	DocContract:  {"Extract obligations and deadlines", "List risks and unusual clauses", "Summarize for execs", "Explain termination terms"},
+	DocFinancial: {"Summarize for execs", "Extract key metrics", "List risks and outlook", "Compare to prior period"},
+	DocMeeting:   {"Extract action items with owners", "Summarize decisions", "List open questions", "Write a follow-up email"},
+	DocResearch:  {"Summarize the findings", "Explain the methodology", "List limitations", "Write a plain-language abstract"},
+	DocResume:    {"Summarize the candidate", "List key skills", "Draft interview questions", "Spot gaps or red flags"},
+	DocGeneral:   {"Summarize in 5 bullets", "Extract key facts", "Summarize for execs", "List open questions"},
+}
+
+// Classify guesses the document type from its title and content
+func Classify(title, content string) DocType {
+	// The opening of a document carries most of its type signal
+	sample := content
+	if len(sample) > 8000 {
+		sample = sample[:8000]
+	}
+	text := strings.ToLower(title + "\n" + sample)
+
+	best, bestHits := DocGeneral, 0
+	for _, t := range []DocType{DocContract, DocFinancial, DocMeeting, DocResearch, DocResume} {
+		hits := 0
+		for _, kw := range docTypeSignals[t] {
+			if strings.Contains(text, kw) {
+				hits++
+			}
+		}
+		if hits > bestHits {
+			best, bestHits = t, hits
+		}
+	}
+
+	// A couple of stray keywords isn't enough to commit to a type
+	if bestHits < 3 {
+		return DocGeneral
+	}
+	return best
+}
+
+// Suggestions returns up to max instructions for a document type,
+// led by the user's most recent instructions
+func Suggestions(t DocType, recent []string, max int) []string {
+	var result []string
+	seen := make(map[string]bool)
+
+	add := func(s string) {
+		key := strings.ToLower(strings.TrimSpace(s))
+		if key == "" || seen[key] || len(result) >= max {
+			return
+		}
+		seen[key] = true
+		result = append(result, s)
+	}
+
+	// At most one slot for recent usage so type suggestions still show
+	for _, r := range recent {
+		if len(r) <= 40 && !strings.HasPrefix(r, "/") {
+			add(r)
+			break
+		}
+	}
+
+	for _, s := range suggestionsByType[t] {
+		add(s)
+	}
+	return result
+}
+
+const maxRecent = 20
+
+func recentPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "recent_instructions"), nil
+}
+
+// LoadRecent returns recently used document instructions, newest first
+func LoadRecent() []string {
+	path, err := recentPath()
+	if err != nil {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var recent []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			recent = append(recent, line)
+		}
+	}
+	return recent
+}
+
+// RecordRecent saves an instruction to the recent list
+func RecordRecent(instruction string) error {
+	instruction = strings.TrimSpace(strings.ReplaceAll(instruction, "\n", " "))
+	if instruction == "" {
+		return nil
+	}
+
+	recent := []string{instruction}
+	for _, r := range LoadRecent() {
+		if !strings.EqualFold(r, instruction) && len(recent) < maxRecent {
+			recent = append(recent, r)
+		}
+	}
+
+	path, err := recentPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strings.Join(recent, "\n")+"\n"), 0600)
+}
@@ -34,6 +34,10 @@ func (a *AnthropicProvider) Name() string {
 	return "anthropic"
 }
 
+func (a *AnthropicProvider) Capabilities() Capabilities {
+	return anthropicCapabilities(a.model)
+}
+
 func (a *AnthropicProvider) Ping(ctx context.Context) error {
 	// Anthropic doesn't have a simple ping endpoint, so we do a minimal request
 	req, err := http.NewRequestWithContext(ctx, "POST",
@@ -0,0 +1,75 @@
+package llm
+
+import (
+	"context"
+	"sync"
+)
+
+// Canceler lets operations be canceled from another goroutine, like the
+// TUI's Esc key. Every operation started from it gets a context that
+// Cancel cancels, which aborts its provider requests. The zero value is
+// ready to use.
+type Canceler struct {
+	mu      sync.Mutex
+	next    int
+	cancels map[int]context.CancelFunc
+}
+
+// Start derives a context for one operation. Call done when the
+// operation ends.
+func (c *Canceler) Start(parent context.Context) (ctx context.Context, done func()) {
+	ctx, cancel := context.WithCancel(parent)
+
+	c.mu.Lock()
+	if c.cancels == nil {
+		c.cancels = make(map[int]context.CancelFunc)
+	}
+	id := c.next
+	c.next++
+	c.cancels[id] = cancel
+	c.mu.Unlock()
+
+	return ctx, func() {
+		c.mu.Lock()
+		delete(c.cancels, id)
+		c.mu.Unlock()
+		cancel()
+	}
+}
+
+// Cancel cancels every operation in progress. Operations started later
+// are unaffected.
+func (c *Canceler) Cancel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, cancel := range c.cancels {
+		cancel()
+		delete(c.cancels, id)
+	}
+}
+
+// Relay forwards events until the stream ends or ctx is canceled, then
+// calls done. After a cancel it keeps draining the provider's stream so
+// the request is cleaned up, and delivers ctx's error as a final event.
+func Relay(ctx context.Context, events <-chan StreamEvent, done func()) <-chan StreamEvent {
+	// Room for the final error, so it never blocks a consumer that left
+	out := make(chan StreamEvent, 1)
+	go func() {
+		defer close(out)
+		defer done()
+		finished := false
+		for ev := range events {
+			select {
+			case out <- ev:
+				finished = ev.Done || ev.Error != nil
+			case <-ctx.Done():
+				for range events {
+				}
+			}
+		}
+		if err := ctx.Err(); err != nil && !finished {
+			out <- StreamEvent{Error: err}
+		}
+	}()
+	return out
+}
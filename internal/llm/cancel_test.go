@@ -0,0 +1,53 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCancelerStopsStream(t *testing.T) {
+	// Provider stream that sends until its request is canceled
+	events := make(chan StreamEvent)
+	var c Canceler
+	ctx, done := c.Start(context.Background())
+	go func() {
+		defer close(events)
+		for {
+			select {
+			case events <- StreamEvent{Chunk: "x"}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	released := make(chan struct{})
+	stream := Relay(ctx, events, func() {
+		done()
+		close(released)
+	})
+	<-stream
+	c.Cancel()
+
+	var last StreamEvent
+	for ev := range stream {
+		last = ev
+	}
+	if !errors.Is(last.Error, context.Canceled) {
+		t.Errorf("last event error = %v, want context.Canceled", last.Error)
+	}
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("stream was not cleaned up after Cancel")
+	}
+
+	// Later operations are unaffected
+	ctx2, done2 := c.Start(context.Background())
+	defer done2()
+	if ctx2.Err() != nil {
+		t.Error("new operation started canceled")
+	}
+}
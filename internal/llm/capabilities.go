@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"context"
+	"strings"
+)
+
+// Capabilities describes what a provider's model can do, so callers can
+// pick a code path the model supports
+type Capabilities struct {
+	Streaming bool // Incremental responses from Stream
+	JSONMode  bool // Honors CompletionRequest.JSON
+	Vision    bool // Accepts images
+	Tools     bool // Function calling
+
+	// Most tokens the model writes in one response, 0 if unknown
+	MaxOutputTokens int
+}
+
+// CapabilitiesOf returns what p's model supports. Providers describe
+// themselves by implementing Capabilities() Capabilities; others (and a
+// nil provider, as in dry runs) are assumed to stream and nothing more.
+func CapabilitiesOf(p Provider) Capabilities {
+	if c, ok := p.(interface{ Capabilities() Capabilities }); ok {
+		return c.Capabilities()
+	}
+	return Capabilities{Streaming: true}
+}
+
+// OutputTokens caps a requested token limit at the model's maximum
+func (c Capabilities) OutputTokens(n int) int {
+	if c.MaxOutputTokens > 0 && n > c.MaxOutputTokens {
+		return c.MaxOutputTokens
+	}
+	return n
+}
+
+// StreamOrComplete streams req, or for providers that can't stream
+// makes one Complete call and delivers the response as a single chunk
+func StreamOrComplete(ctx context.Context, p Provider, req *CompletionRequest) (<-chan StreamEvent, error) {
+	if CapabilitiesOf(p).Streaming {
+		return p.Stream(ctx, req)
+	}
+	resp, err := p.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return SingleEvent(resp.Content), nil
+}
+
+// SingleEvent delivers a complete response as a one-chunk stream
+func SingleEvent(content string) <-chan StreamEvent {
+	ch := make(chan StreamEvent, 2)
+	ch <- StreamEvent{Chunk: content}
+	ch <- StreamEvent{Done: true}
+	close(ch)
+	return ch
+}
+
+// outputLimit maps a model name prefix to its output token limit; the
+// first match wins, so longer prefixes come first
+type outputLimit struct {
+	prefix string
+	tokens int
+}
+
+func lookupOutputLimit(model string, limits []outputLimit) int {
+	model = strings.ToLower(model)
+	for _, l := range limits {
+		if strings.HasPrefix(model, l.prefix) {
+			return l.tokens
+		}
+	}
+	return 0
+}
+
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	s = strings.ToLower(s)
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(s string, parts ...string) bool {
+	s = strings.ToLower(s)
+	for _, p := range parts {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+var openAIOutputLimits = []outputLimit{
+	{"gpt-5", 128000},
+	{"gpt-4.1", 32768},
+	{"gpt-4o", 16384},
+	{"gpt-4-turbo", 4096},
+	{"gpt-4", 8192},
+	{"gpt-3.5", 4096},
+	{"o1-mini", 65536},
+	{"o1", 100000},
+	{"o3", 100000},
+	{"o4", 100000},
+}
+
+func openAICapabilities(model string) Capabilities {
+	return Capabilities{
+		Streaming:       true,
+		JSONMode:        true,
+		Vision:          hasAnyPrefix(model, "gpt-4o", "gpt-4.1", "gpt-4-turbo", "gpt-5", "o1", "o3", "o4") && !hasAnyPrefix(model, "o1-mini", "o3-mini"),
+		Tools:           !hasAnyPrefix(model, "o1-mini"),
+		MaxOutputTokens: lookupOutputLimit(model, openAIOutputLimits),
+	}
+}
+
+var anthropicOutputLimits = []outputLimit{
+	{"claude-opus-4", 32000},
+	{"claude-sonnet-4", 64000},
+	{"claude-3-7", 64000},
+	{"claude-3-5", 8192},
+	{"claude-3", 4096},
+}
+
+func anthropicCapabilities(model string) Capabilities {
+	return Capabilities{
+		Streaming:       true,
+		Vision:          true,
+		Tools:           true,
+		MaxOutputTokens: lookupOutputLimit(model, anthropicOutputLimits),
+	}
+}
+
+func groqCapabilities(model string) Capabilities {
+	return Capabilities{
+		Streaming:       true,
+		JSONMode:        true,
+		Vision:          containsAny(model, "vision", "llama-4"),
+		Tools:           true,
+		MaxOutputTokens: 8192,
+	}
+}
+
+func ollamaCapabilities(model string) Capabilities {
+	return Capabilities{
+		Streaming: true,
+		JSONMode:  true,
+		Vision:    containsAny(model, "llava", "vision", "moondream", "minicpm-v", "gemma3"),
+	}
+}
@@ -0,0 +1,62 @@
+package llm
+
+import (
+	"context"
+	"testing"
+)
+
+// batchOnly is a provider that can't stream
+type batchOnly struct{ slowProvider }
+
+func (b *batchOnly) Capabilities() Capabilities { return Capabilities{MaxOutputTokens: 100} }
+func (b *batchOnly) Complete(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	return &CompletionResponse{Content: "whole answer"}, nil
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	if c := CapabilitiesOf(nil); !c.Streaming || c.JSONMode {
+		t.Errorf("CapabilitiesOf(nil) = %+v", c)
+	}
+
+	// Limits pass capabilities through
+	p := WithLimit(&batchOnly{}, 2)
+	c := CapabilitiesOf(p)
+	if c.Streaming || c.MaxOutputTokens != 100 {
+		t.Errorf("CapabilitiesOf(limited) = %+v", c)
+	}
+	if c.OutputTokens(4096) != 100 || c.OutputTokens(50) != 50 {
+		t.Errorf("OutputTokens() = %d, %d", c.OutputTokens(4096), c.OutputTokens(50))
+	}
+
+	tests := []struct {
+		p      Provider
+		tokens int
+		vision bool
+	}{
+		{NewOpenAIProvider("k", "gpt-4o-mini"), 16384, true},
+		{NewOpenAIProvider("k", "gpt-4"), 8192, false},
+		{NewAnthropicProvider("k", "claude-3-5-haiku-latest"), 8192, true},
+		{NewOllamaProvider("", "llava:13b"), 0, true},
+		{NewCustomProvider("http://localhost:8080/v1", "", "gpt-4o"), 0, false},
+	}
+	for _, tt := range tests {
+		c := CapabilitiesOf(tt.p)
+		if c.MaxOutputTokens != tt.tokens || c.Vision != tt.vision {
+			t.Errorf("%s: Capabilities() = %+v", tt.p.Name(), c)
+		}
+	}
+}
+
+func TestStreamOrCompleteFallsBack(t *testing.T) {
+	events, err := StreamOrComplete(context.Background(), &batchOnly{}, &CompletionRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got string
+	for e := range events {
+		got += e.Chunk
+	}
+	if got != "whole answer" {
+		t.Errorf("streamed %q", got)
+	}
+}
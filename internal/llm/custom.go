@@ -25,3 +25,9 @@ func NewCustomProvider(baseURL, apiKey, model string) *CustomProvider {
 func (c *CustomProvider) Name() string {
 	return "custom"
 }
+
+// Capabilities of an OpenAI-compatible server are unknown; JSON mode in
+// particular is rejected by some, so only streaming is assumed
+func (c *CustomProvider) Capabilities() Capabilities {
+	return Capabilities{Streaming: true}
+}
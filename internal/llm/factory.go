@@ -2,19 +2,68 @@ package llm
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/sant0-9/pulp/internal/config"
 )
 
-// NewProvider creates a provider from config
+// NewProvider creates a provider from config, limited to the
+// configured number of requests in flight. Providers for the same
+// server share the limit.
 func NewProvider(cfg *config.Config) (Provider, error) {
+	return NewProviderWithClient(cfg, nil)
+}
+
+// NewProviderWithClient is NewProvider with the provider's API requests
+// going through client, e.g. one pointed at a fake API in tests. A nil
+// client keeps the provider's own.
+func NewProviderWithClient(cfg *config.Config, client *http.Client) (Provider, error) {
+	p, err := newProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if client != nil {
+		setHTTPClient(p, client)
+	}
+	return withSharedLimit(p, cfg.Provider, providerHost(cfg), cfg.InFlightLimit(cfg.Provider)), nil
+}
+
+// providerHost returns the server a provider config talks to, for
+// providers that don't have a fixed API
+func providerHost(cfg *config.Config) string {
 	switch cfg.Provider {
 	case "ollama":
-		host := "http://localhost:11434"
 		if cfg.BaseURL != "" {
-			host = cfg.BaseURL
+			return cfg.BaseURL
 		}
-		return NewOllamaProvider(host, cfg.Model), nil
+		return defaultOllamaHost
+	case "custom":
+		return cfg.BaseURL
+	}
+	return ""
+}
+
+func setHTTPClient(p Provider, client *http.Client) {
+	switch p := p.(type) {
+	case *OpenAIProvider:
+		p.httpClient = client
+	case *CustomProvider:
+		p.httpClient = client
+	case *OpenRouterProvider:
+		p.httpClient = client
+	case *GroqProvider:
+		p.httpClient = client
+	case *AnthropicProvider:
+		p.httpClient = client
+	case *OllamaProvider:
+		p.httpClient = client
+	}
+}
+
+func newProvider(cfg *config.Config) (Provider, error) {
+	switch cfg.Provider {
+	case "ollama":
+		return NewOllamaProvider(providerHost(cfg), cfg.Model), nil
 
 	case "groq":
 		if cfg.APIKey == "" {
@@ -59,7 +108,12 @@ func NewLocalProvider(cfg *config.Config) (Provider, error) {
 
 	switch cfg.Local.Provider {
 	case "ollama":
-		return NewOllamaProvider(cfg.Local.Host, cfg.Local.Model), nil
+		host := cfg.Local.Host
+		if host == "" {
+			host = defaultOllamaHost
+		}
+		p := NewOllamaProvider(host, cfg.Local.Model)
+		return withSharedLimit(p, "ollama", host, cfg.InFlightLimit("ollama")), nil
 	default:
 		return nil, fmt.Errorf("unknown local provider: %s", cfg.Local.Provider)
 	}
@@ -34,6 +34,10 @@ func (g *GroqProvider) Name() string {
 	return "groq"
 }
 
+func (g *GroqProvider) Capabilities() Capabilities {
+	return groqCapabilities(g.model)
+}
+
 func (g *GroqProvider) Ping(ctx context.Context) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.groq.com/openai/v1/models", nil)
 	if err != nil {
@@ -64,6 +68,22 @@ type openAIRequest struct {
 	MaxTokens   int             `json:"max_tokens,omitempty"`
 	Temperature float64         `json:"temperature,omitempty"`
 	Stream      bool            `json:"stream"`
+
+	// {"type": "json_object"} when the request asks for JSON
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+// jsonFormat asks OpenAI-compatible APIs for a JSON object if the
+// request wants one
+func jsonFormat(req *CompletionRequest) *openAIResponseFormat {
+	if !req.JSON {
+		return nil
+	}
+	return &openAIResponseFormat{Type: "json_object"}
 }
 
 type openAIMessage struct {
@@ -100,11 +120,12 @@ func (g *GroqProvider) Complete(ctx context.Context, req *CompletionRequest) (*C
 	}
 
 	apiReq := openAIRequest{
-		Model:       model,
-		Messages:    toOpenAIMessages(req.Messages),
-		MaxTokens:   req.MaxTokens,
-		Temperature: req.Temperature,
-		Stream:      false,
+		Model:          model,
+		Messages:       toOpenAIMessages(req.Messages),
+		MaxTokens:      req.MaxTokens,
+		Temperature:    req.Temperature,
+		Stream:         false,
+		ResponseFormat: jsonFormat(req),
 	}
 
 	body, _ := json.Marshal(apiReq)
@@ -157,11 +178,12 @@ func (g *GroqProvider) Stream(ctx context.Context, req *CompletionRequest) (<-ch
 	}
 
 	apiReq := openAIRequest{
-		Model:       model,
-		Messages:    toOpenAIMessages(req.Messages),
-		MaxTokens:   req.MaxTokens,
-		Temperature: req.Temperature,
-		Stream:      true,
+		Model:          model,
+		Messages:       toOpenAIMessages(req.Messages),
+		MaxTokens:      req.MaxTokens,
+		Temperature:    req.Temperature,
+		Stream:         true,
+		ResponseFormat: jsonFormat(req),
 	}
 
 	body, _ := json.Marshal(apiReq)
@@ -0,0 +1,101 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// limitedProvider caps the number of requests in flight to a provider.
+// A stream holds its slot until the stream ends.
+type limitedProvider struct {
+	Provider
+	slots chan struct{}
+}
+
+// WithLimit wraps p so at most n requests run at once. Everything
+// sharing the returned provider shares the limit.
+func WithLimit(p Provider, n int) Provider {
+	if n <= 0 {
+		return p
+	}
+	return &limitedProvider{Provider: p, slots: make(chan struct{}, n)}
+}
+
+// Slots shared by every provider built for the same server, so two
+// providers (say the TUI's and a /anonymize run's) don't each get the
+// full limit
+var (
+	sharedMu    sync.Mutex
+	sharedSlots = make(map[string]chan struct{})
+)
+
+// withSharedLimit is WithLimit with the limit shared by all providers
+// wrapped with the same name and host. A changed limit (after a config
+// edit) starts a new set of slots.
+func withSharedLimit(p Provider, name, host string, n int) Provider {
+	if n <= 0 {
+		return p
+	}
+
+	key := name + " " + strings.TrimSuffix(host, "/")
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	slots, ok := sharedSlots[key]
+	if !ok || cap(slots) != n {
+		slots = make(chan struct{}, n)
+		sharedSlots[key] = slots
+	}
+	return &limitedProvider{Provider: p, slots: slots}
+}
+
+// MaxInFlight returns the provider's concurrency limit, or 1 for
+// providers without one. Wrappers report their inner provider's limit
+// by implementing MaxInFlight() int.
+func MaxInFlight(p Provider) int {
+	if l, ok := p.(interface{ MaxInFlight() int }); ok {
+		return l.MaxInFlight()
+	}
+	return 1
+}
+
+func (l *limitedProvider) MaxInFlight() int {
+	return cap(l.slots)
+}
+
+func (l *limitedProvider) Capabilities() Capabilities {
+	return CapabilitiesOf(l.Provider)
+}
+
+func (l *limitedProvider) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *limitedProvider) release() {
+	<-l.slots
+}
+
+func (l *limitedProvider) Complete(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	if err := l.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer l.release()
+	return l.Provider.Complete(ctx, req)
+}
+
+func (l *limitedProvider) Stream(ctx context.Context, req *CompletionRequest) (<-chan StreamEvent, error) {
+	if err := l.acquire(ctx); err != nil {
+		return nil, err
+	}
+	events, err := l.Provider.Stream(ctx, req)
+	if err != nil {
+		l.release()
+		return nil, err
+	}
+	return Relay(ctx, events, l.release), nil
+}
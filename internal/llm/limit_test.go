@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowProvider records the most requests it saw at once
+type slowProvider struct {
+	inFlight, peak atomic.Int32
+}
+
+func (s *slowProvider) Name() string                   { return "slow" }
+func (s *slowProvider) Ping(ctx context.Context) error { return nil }
+func (s *slowProvider) Stream(ctx context.Context, req *CompletionRequest) (<-chan StreamEvent, error) {
+	return nil, nil
+}
+func (s *slowProvider) Complete(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.peak.Load()
+		if n <= peak || s.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return &CompletionResponse{}, nil
+}
+
+func TestWithLimit(t *testing.T) {
+	inner := &slowProvider{}
+	p := WithLimit(inner, 2)
+	if MaxInFlight(p) != 2 || MaxInFlight(inner) != 1 {
+		t.Errorf("MaxInFlight() = %d, %d", MaxInFlight(p), MaxInFlight(inner))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Complete(context.Background(), &CompletionRequest{})
+		}()
+	}
+	wg.Wait()
+
+	if peak := inner.peak.Load(); peak != 2 {
+		t.Errorf("peak in flight = %d, want 2", peak)
+	}
+}
+
+func TestSharedLimit(t *testing.T) {
+	// Two providers for one server, as when the TUI and /anonymize both
+	// build one, share the slots
+	inner := &slowProvider{}
+	a := withSharedLimit(inner, "test", "http://gpu-box:11434/", 2)
+	b := withSharedLimit(inner, "test", "http://gpu-box:11434", 2)
+	other := withSharedLimit(&slowProvider{}, "test", "http://other:11434", 2)
+	if a.(*limitedProvider).slots != b.(*limitedProvider).slots || a.(*limitedProvider).slots == other.(*limitedProvider).slots {
+		t.Fatal("slots not shared by host")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(p Provider) {
+			defer wg.Done()
+			p.Complete(context.Background(), &CompletionRequest{})
+		}([]Provider{a, b}[i%2])
+	}
+	wg.Wait()
+
+	if peak := inner.peak.Load(); peak != 2 {
+		t.Errorf("peak in flight across both = %d, want 2", peak)
+	}
+}
@@ -0,0 +1,299 @@
+// Package llmtest serves fake provider APIs over HTTP for tests: the
+// OpenAI chat API and its compatibles (Groq, OpenRouter, custom
+// endpoints), Anthropic's messages API and Ollama's chat API. Streams
+// arrive the way each API sends them, as SSE events or NDJSON lines.
+//
+// Point a provider's HTTP client at the server with Transport, which
+// sends requests for any host to the fake.
+package llmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Message is a chat message as the fake received it
+type Message struct {
+	Role    string
+	Content string
+}
+
+// Request is a completion request as the fake received it. System
+// prompts come first in Messages for every API.
+type Request struct {
+	Path      string
+	Model     string
+	Messages  []Message
+	MaxTokens int
+	Stream    bool
+	JSON      bool // response_format json_object, or Ollama's format: json
+}
+
+// System returns the request's system prompt
+func (r Request) System() string {
+	if len(r.Messages) > 0 && r.Messages[0].Role == "system" {
+		return r.Messages[0].Content
+	}
+	return ""
+}
+
+// Server is a fake provider API
+type Server struct {
+	*httptest.Server
+
+	// APIKey is the key requests must carry (Bearer or x-api-key);
+	// empty accepts any
+	APIKey string
+
+	mu       sync.Mutex
+	reply    func(Request) string
+	status   int
+	requests []Request
+}
+
+// DefaultReply is what completions return until SetReply or Reply
+const DefaultReply = "Hello from the fake API"
+
+// NewServer starts a fake API that is closed when the test ends
+func NewServer(t testing.TB) *Server {
+	s := &Server{reply: func(Request) string { return DefaultReply }}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// SetReply makes every completion return text
+func (s *Server) SetReply(text string) {
+	s.Reply(func(Request) string { return text })
+}
+
+// Reply sets a function that answers each completion request
+func (s *Server) Reply(fn func(Request) string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reply = fn
+}
+
+// FailWith makes completions fail with an HTTP status; 0 succeeds again
+func (s *Server) FailWith(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+// Requests returns the completion requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Transport sends requests for any host to the fake, so providers with
+// built-in API URLs can be tested unchanged
+func (s *Server) Transport() http.RoundTripper {
+	target, _ := url.Parse(s.URL)
+	return &redirect{target: target, base: s.Client().Transport}
+}
+
+type redirect struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (r *redirect) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	req.Host = ""
+	return r.base.RoundTrip(req)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	switch {
+	case r.Method == http.MethodGet && path == "/api/tags":
+		writeJSON(w, map[string]any{"models": []any{}})
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/models"):
+		if s.authorized(w, r) {
+			writeJSON(w, map[string]any{"data": []any{}})
+		}
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/chat/completions"):
+		s.complete(w, r, openAIAPI)
+	case r.Method == http.MethodPost && path == "/v1/messages":
+		s.complete(w, r, anthropicAPI)
+	case r.Method == http.MethodPost && path == "/api/chat":
+		s.complete(w, r, ollamaAPI)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+type api int
+
+const (
+	openAIAPI api = iota
+	anthropicAPI
+	ollamaAPI
+)
+
+// authorized checks the request's key, answering 401 if it's wrong
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if s.APIKey == "" {
+		return true
+	}
+	key := r.Header.Get("x-api-key")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		key = bearer
+	}
+	if key != s.APIKey {
+		http.Error(w, `{"error":{"message":"invalid api key"}}`, http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// body is the union of the three request formats
+type body struct {
+	Model          string    `json:"model"`
+	System         string    `json:"system"`
+	Messages       []Message `json:"messages"`
+	MaxTokens      int       `json:"max_tokens"`
+	Stream         bool      `json:"stream"`
+	Format         string    `json:"format"`
+	ResponseFormat *struct {
+		Type string `json:"type"`
+	} `json:"response_format"`
+	Options *struct {
+		NumPredict int `json:"num_predict"`
+	} `json:"options"`
+}
+
+func (s *Server) complete(w http.ResponseWriter, r *http.Request, kind api) {
+	if kind != ollamaAPI && !s.authorized(w, r) {
+		return
+	}
+
+	var b body
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := Request{
+		Path:      r.URL.Path,
+		Model:     b.Model,
+		Messages:  b.Messages,
+		MaxTokens: b.MaxTokens,
+		Stream:    b.Stream,
+		JSON:      b.Format == "json" || (b.ResponseFormat != nil && b.ResponseFormat.Type == "json_object"),
+	}
+	if b.System != "" {
+		req.Messages = append([]Message{{Role: "system", Content: b.System}}, req.Messages...)
+	}
+	if b.Options != nil {
+		req.MaxTokens = b.Options.NumPredict
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	status, reply := s.status, s.reply
+	s.mu.Unlock()
+
+	if status != 0 {
+		http.Error(w, fmt.Sprintf(`{"error":{"message":"fake failure %d"}}`, status), status)
+		return
+	}
+
+	text := reply(req)
+	if !req.Stream {
+		switch kind {
+		case openAIAPI:
+			writeJSON(w, map[string]any{
+				"choices": []any{map[string]any{
+					"message":       map[string]string{"role": "assistant", "content": text},
+					"finish_reason": "stop",
+				}},
+			})
+		case anthropicAPI:
+			writeJSON(w, map[string]any{
+				"content":     []any{map[string]string{"type": "text", "text": text}},
+				"stop_reason": "end_turn",
+			})
+		case ollamaAPI:
+			writeJSON(w, map[string]any{
+				"model":       req.Model,
+				"message":     map[string]string{"role": "assistant", "content": text},
+				"done":        true,
+				"done_reason": "stop",
+			})
+		}
+		return
+	}
+
+	stream(w, kind, req.Model, chunks(text))
+}
+
+// chunks splits a reply into word-sized stream chunks
+func chunks(text string) []string {
+	return strings.SplitAfter(text, " ")
+}
+
+// stream writes the chunks in the API's streaming format
+func stream(w http.ResponseWriter, kind api, model string, parts []string) {
+	flusher, _ := w.(http.Flusher)
+	flush := func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	event := func(name string, v any) {
+		data, _ := json.Marshal(v)
+		if name != "" {
+			fmt.Fprintf(w, "event: %s\n", name)
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flush()
+	}
+
+	switch kind {
+	case openAIAPI:
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, p := range parts {
+			event("", map[string]any{"choices": []any{map[string]any{"delta": map[string]string{"content": p}}}})
+		}
+		event("", map[string]any{"choices": []any{map[string]any{"delta": map[string]string{}, "finish_reason": "stop"}}})
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		flush()
+
+	case anthropicAPI:
+		w.Header().Set("Content-Type", "text/event-stream")
+		event("message_start", map[string]any{"type": "message_start"})
+		for _, p := range parts {
+			event("content_block_delta", map[string]any{
+				"type":  "content_block_delta",
+				"delta": map[string]string{"type": "text_delta", "text": p},
+			})
+		}
+		event("message_stop", map[string]any{"type": "message_stop"})
+
+	case ollamaAPI:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(w)
+		for _, p := range parts {
+			enc.Encode(map[string]any{"model": model, "message": map[string]string{"role": "assistant", "content": p}, "done": false})
+			flush()
+		}
+		enc.Encode(map[string]any{"model": model, "message": map[string]string{"role": "assistant", "content": ""}, "done": true})
+		flush()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 )
 
+const defaultOllamaHost = "http://localhost:11434"
+
 type OllamaProvider struct {
 	host       string
 	model      string
@@ -19,7 +21,7 @@ type OllamaProvider struct {
 
 func NewOllamaProvider(host, model string) *OllamaProvider {
 	if host == "" {
-		host = "http://localhost:11434"
+		host = defaultOllamaHost
 	}
 	return &OllamaProvider{
 		host:  host,
@@ -34,6 +36,10 @@ func (o *OllamaProvider) Name() string {
 	return "ollama"
 }
 
+func (o *OllamaProvider) Capabilities() Capabilities {
+	return ollamaCapabilities(o.model)
+}
+
 func (o *OllamaProvider) Ping(ctx context.Context) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", o.host+"/api/tags", nil)
 	if err != nil {
@@ -57,6 +63,7 @@ type ollamaChatRequest struct {
 	Model    string          `json:"model"`
 	Messages []ollamaMessage `json:"messages"`
 	Stream   bool            `json:"stream"`
+	Format   string          `json:"format,omitempty"` // "json" constrains output to JSON
 	Options  *ollamaOptions  `json:"options,omitempty"`
 }
 
@@ -87,6 +94,7 @@ func (o *OllamaProvider) Complete(ctx context.Context, req *CompletionRequest) (
 		Model:    model,
 		Messages: convertMessages(req.Messages),
 		Stream:   false,
+		Format:   ollamaFormat(req),
 		Options: &ollamaOptions{
 			Temperature: req.Temperature,
 			NumPredict:  req.MaxTokens,
@@ -137,6 +145,7 @@ func (o *OllamaProvider) Stream(ctx context.Context, req *CompletionRequest) (<-
 		Model:    model,
 		Messages: convertMessages(req.Messages),
 		Stream:   true,
+		Format:   ollamaFormat(req),
 		Options: &ollamaOptions{
 			Temperature: req.Temperature,
 			NumPredict:  req.MaxTokens,
@@ -200,6 +209,14 @@ func (o *OllamaProvider) Stream(ctx context.Context, req *CompletionRequest) (<-
 	return events, nil
 }
 
+// ollamaFormat asks Ollama for JSON output if the request wants it
+func ollamaFormat(req *CompletionRequest) string {
+	if req.JSON {
+		return "json"
+	}
+	return ""
+}
+
 func convertMessages(msgs []Message) []ollamaMessage {
 	result := make([]ollamaMessage, len(msgs))
 	for i, m := range msgs {
@@ -36,6 +36,10 @@ func (o *OpenAIProvider) Name() string {
 	return "openai"
 }
 
+func (o *OpenAIProvider) Capabilities() Capabilities {
+	return openAICapabilities(o.model)
+}
+
 func (o *OpenAIProvider) Ping(ctx context.Context) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", o.baseURL+"/models", nil)
 	if err != nil {
@@ -66,11 +70,12 @@ func (o *OpenAIProvider) Complete(ctx context.Context, req *CompletionRequest) (
 	}
 
 	apiReq := openAIRequest{
-		Model:       model,
-		Messages:    toOpenAIMessages(req.Messages),
-		MaxTokens:   req.MaxTokens,
-		Temperature: req.Temperature,
-		Stream:      false,
+		Model:          model,
+		Messages:       toOpenAIMessages(req.Messages),
+		MaxTokens:      req.MaxTokens,
+		Temperature:    req.Temperature,
+		Stream:         false,
+		ResponseFormat: jsonFormat(req),
 	}
 
 	body, _ := json.Marshal(apiReq)
@@ -123,11 +128,12 @@ func (o *OpenAIProvider) Stream(ctx context.Context, req *CompletionRequest) (<-
 	}
 
 	apiReq := openAIRequest{
-		Model:       model,
-		Messages:    toOpenAIMessages(req.Messages),
-		MaxTokens:   req.MaxTokens,
-		Temperature: req.Temperature,
-		Stream:      true,
+		Model:          model,
+		Messages:       toOpenAIMessages(req.Messages),
+		MaxTokens:      req.MaxTokens,
+		Temperature:    req.Temperature,
+		Stream:         true,
+		ResponseFormat: jsonFormat(req),
 	}
 
 	body, _ := json.Marshal(apiReq)
@@ -28,3 +28,9 @@ func NewOpenRouterProvider(apiKey, model string) *OpenRouterProvider {
 func (o *OpenRouterProvider) Name() string {
 	return "openrouter"
 }
+
+// Capabilities depend on the model OpenRouter routes to, so only
+// streaming is assumed
+func (o *OpenRouterProvider) Capabilities() Capabilities {
+	return Capabilities{Streaming: true}
+}
@@ -25,6 +25,10 @@ type CompletionRequest struct {
 	Messages    []Message
 	MaxTokens   int
 	Temperature float64
+
+	// Constrain the response to a JSON object. Only set it when the
+	// provider's Capabilities has JSONMode.
+	JSON bool
 }
 
 // Message represents a chat message
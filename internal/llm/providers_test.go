@@ -0,0 +1,134 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/llm/llmtest"
+)
+
+// fakeProviders returns every provider with its HTTP client pointed at
+// the fake API
+func fakeProviders(srv *llmtest.Server, key string) []Provider {
+	client := &http.Client{Transport: srv.Transport()}
+
+	openai := NewOpenAIProvider(key, "gpt-4o-mini")
+	openai.httpClient = client
+	groq := NewGroqProvider(key, "")
+	groq.httpClient = client
+	openrouter := NewOpenRouterProvider(key, "")
+	openrouter.httpClient = client
+	custom := NewCustomProvider("http://llm.internal/v1", key, "local")
+	custom.httpClient = client
+	anthropic := NewAnthropicProvider(key, "")
+	anthropic.httpClient = client
+	ollama := NewOllamaProvider("", "llama3.2")
+	ollama.httpClient = client
+
+	return []Provider{openai, groq, openrouter, custom, anthropic, ollama}
+}
+
+func collect(t *testing.T, events <-chan StreamEvent) string {
+	t.Helper()
+	var b strings.Builder
+	done := false
+	for e := range events {
+		if e.Error != nil {
+			t.Fatalf("stream error: %v", e.Error)
+		}
+		if e.Done {
+			done = true
+		}
+		b.WriteString(e.Chunk)
+	}
+	if !done {
+		t.Error("stream ended without Done")
+	}
+	return b.String()
+}
+
+func TestProvidersAgainstFakeAPIs(t *testing.T) {
+	srv := llmtest.NewServer(t)
+	srv.APIKey = "test-key"
+	ctx := context.Background()
+
+	for _, p := range fakeProviders(srv, "test-key") {
+		t.Run(p.Name(), func(t *testing.T) {
+			if err := p.Ping(ctx); err != nil {
+				t.Fatalf("Ping() = %v", err)
+			}
+
+			req := &CompletionRequest{Messages: []Message{
+				{Role: "system", Content: "Be brief."},
+				{Role: "user", Content: "Hi"},
+			}, MaxTokens: 100}
+			resp, err := p.Complete(ctx, req)
+			if err != nil {
+				t.Fatalf("Complete() = %v", err)
+			}
+			if resp.Content != llmtest.DefaultReply {
+				t.Errorf("Complete() = %q", resp.Content)
+			}
+
+			events, err := p.Stream(ctx, req)
+			if err != nil {
+				t.Fatalf("Stream() = %v", err)
+			}
+			if got := collect(t, events); got != llmtest.DefaultReply {
+				t.Errorf("Stream() = %q", got)
+			}
+
+			reqs := srv.Requests()
+			last := reqs[len(reqs)-1]
+			if last.System() != "Be brief." || last.MaxTokens != 100 || !last.Stream {
+				t.Errorf("API got %+v", last)
+			}
+		})
+	}
+}
+
+func TestProvidersReportAPIErrors(t *testing.T) {
+	srv := llmtest.NewServer(t)
+	srv.APIKey = "test-key"
+	ctx := context.Background()
+
+	for _, p := range fakeProviders(srv, "wrong-key") {
+		if p.Name() == "ollama" {
+			continue // No keys
+		}
+		if err := p.Ping(ctx); err == nil || !strings.Contains(err.Error(), "invalid API key") {
+			t.Errorf("%s: Ping() with a bad key = %v", p.Name(), err)
+		}
+	}
+
+	srv.FailWith(http.StatusInternalServerError)
+	for _, p := range fakeProviders(srv, "test-key") {
+		req := &CompletionRequest{Messages: []Message{{Role: "user", Content: "Hi"}}}
+		if _, err := p.Complete(ctx, req); err == nil || !strings.Contains(err.Error(), "500") {
+			t.Errorf("%s: Complete() = %v, want status 500", p.Name(), err)
+		}
+		if _, err := p.Stream(ctx, req); err == nil || !strings.Contains(err.Error(), "500") {
+			t.Errorf("%s: Stream() = %v, want status 500", p.Name(), err)
+		}
+	}
+}
+
+func TestJSONModeReachesAPI(t *testing.T) {
+	srv := llmtest.NewServer(t)
+
+	for _, p := range fakeProviders(srv, "test-key") {
+		req := &CompletionRequest{
+			Messages: []Message{{Role: "user", Content: "Hi"}},
+			JSON:     CapabilitiesOf(p).JSONMode,
+		}
+		if _, err := p.Complete(context.Background(), req); err != nil {
+			t.Fatalf("%s: Complete() = %v", p.Name(), err)
+		}
+		reqs := srv.Requests()
+		if got := reqs[len(reqs)-1].JSON; got != req.JSON {
+			t.Errorf("%s: API got JSON mode %v, want %v", p.Name(), got, req.JSON)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Package output delivers results to sinks: files, the clipboard,
+// stdout, webhooks and external commands. A new integration is one Sink
+// implementation registered under its type name.
+package output
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sant0-9/pulp/internal/config"
+)
+
+// Result is what gets delivered
+type Result struct {
+	Title   string
+	Content string
+}
+
+// Sink is a destination for results
+type Sink interface {
+	// Name returns the configured name, or the type for built-in targets
+	Name() string
+
+	// Send delivers the result and returns where it went, for display
+	Send(ctx context.Context, r Result) (string, error)
+}
+
+// Factory creates a sink from its config
+type Factory func(name string, cfg config.OutputConfig) (Sink, error)
+
+var registry = map[string]Factory{}
+
+// Register makes a sink type available to config and --output
+func Register(kind string, f Factory) {
+	registry[kind] = f
+}
+
+// Types returns the registered sink types, sorted
+func Types() []string {
+	var kinds []string
+	for k := range registry {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// New creates a sink from its config
+func New(name string, cfg config.OutputConfig) (Sink, error) {
+	f, ok := registry[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown output type %q (available: %s)", cfg.Type, strings.Join(Types(), ", "))
+	}
+	return f(name, cfg)
+}
+
+// Open resolves a target to a sink. A target is the name of a sink under
+// outputs: in the config, a sink type ("clipboard"), or a type with its
+// destination ("file:notes.md", "webhook:https://..."). An empty target
+// opens the configured default output.
+func Open(cfg *config.Config, target string) (Sink, error) {
+	if target == "" && cfg != nil {
+		target = cfg.DefaultOutput
+	}
+	if target == "" {
+		target = "file"
+	}
+
+	if cfg != nil {
+		if oc, ok := cfg.Outputs[target]; ok {
+			return New(target, oc)
+		}
+	}
+
+	kind, dest, _ := strings.Cut(target, ":")
+	oc := config.OutputConfig{Type: kind}
+	switch kind {
+	case "file":
+		oc.Path = dest
+	case "webhook":
+		oc.URL = dest
+	case "command":
+		oc.Command = dest
+	default:
+		if dest != "" {
+			return nil, fmt.Errorf("output %q takes no destination", kind)
+		}
+	}
+	return New(kind, oc)
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/config"
+)
+
+func TestOpenFileIntoDirectory(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{
+		Outputs:       map[string]config.OutputConfig{"notes": {Type: "file", Path: dir}},
+		DefaultOutput: "notes",
+	}
+
+	sink, err := Open(cfg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sink.Name() != "notes" {
+		t.Errorf("Name() = %q, want notes", sink.Name())
+	}
+
+	where, err := sink.Send(context.Background(), Result{Title: "Q3 Report", Content: "# Summary"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "Q3_Report_summary.md"); where != want {
+		t.Errorf("saved to %q, want %q", where, want)
+	}
+	if data, _ := os.ReadFile(where); string(data) != "# Summary" {
+		t.Errorf("file content = %q", data)
+	}
+}
+
+func TestWebhookSlackFormat(t *testing.T) {
+	var got map[string]string
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOOK_TOKEN", "secret")
+	sink, err := New("team", config.OutputConfig{
+		Type:    "webhook",
+		URL:     srv.URL,
+		Format:  "slack",
+		Headers: map[string]string{"Authorization": "Bearer $HOOK_TOKEN"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sink.Send(context.Background(), Result{Title: "Digest", Content: "All good"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got["text"] != "*Digest*\n\nAll good" {
+		t.Errorf("text = %q", got["text"])
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q", auth)
+	}
+}
+
+func TestIsStdout(t *testing.T) {
+	cfg := &config.Config{Outputs: map[string]config.OutputConfig{"term": {Type: "stdout"}}}
+	for target, want := range map[string]bool{"stdout": true, "term": true, "clipboard": false} {
+		sink, err := Open(cfg, target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if IsStdout(sink) != want {
+			t.Errorf("IsStdout(%s) = %v, want %v", target, !want, want)
+		}
+	}
+}
+
+func TestOpenRejectsUnknown(t *testing.T) {
+	if _, err := Open(nil, "fax"); err == nil {
+		t.Error("expected an error for an unknown output type")
+	}
+	if _, err := Open(nil, "clipboard:x"); err == nil {
+		t.Error("expected an error for a destination on clipboard")
+	}
+}
@@ -0,0 +1,234 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/sant0-9/pulp/internal/config"
+)
+
+func init() {
+	Register("file", newFileSink)
+	Register("clipboard", newClipboardSink)
+	Register("stdout", newStdoutSink)
+	Register("webhook", newWebhookSink)
+	Register("command", newCommandSink)
+}
+
+// fileSink writes the result to a file, or into a directory named after
+// the result's title
+type fileSink struct {
+	name string
+	path string
+}
+
+func newFileSink(name string, cfg config.OutputConfig) (Sink, error) {
+	return &fileSink{name: name, path: cfg.Path}, nil
+}
+
+func (s *fileSink) Name() string { return s.name }
+
+func (s *fileSink) Send(ctx context.Context, r Result) (string, error) {
+	path := expandHome(s.path)
+	isDir := path == "" || strings.HasSuffix(path, "/")
+	if path == "" {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, "Documents")
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		isDir = true
+	}
+	if isDir {
+		path = filepath.Join(path, FileName(r.Title))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(r.Content), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// FileName returns the file name a result is saved under in a directory
+func FileName(title string) string {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = "pulp"
+	}
+	title = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return -1
+		}
+		return r
+	}, title)
+	return strings.ReplaceAll(title, " ", "_") + "_summary.md"
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, path[1:])
+	}
+	return path
+}
+
+// clipboardSink copies the result to the system clipboard
+type clipboardSink struct {
+	name string
+}
+
+func newClipboardSink(name string, cfg config.OutputConfig) (Sink, error) {
+	return &clipboardSink{name: name}, nil
+}
+
+func (s *clipboardSink) Name() string { return s.name }
+
+func (s *clipboardSink) Send(ctx context.Context, r Result) (string, error) {
+	if err := clipboard.WriteAll(r.Content); err != nil {
+		return "", fmt.Errorf("clipboard unavailable: %w", err)
+	}
+	return "clipboard", nil
+}
+
+// stdoutSink prints the result, for the CLI
+type stdoutSink struct {
+	name string
+	w    io.Writer
+}
+
+func newStdoutSink(name string, cfg config.OutputConfig) (Sink, error) {
+	return &stdoutSink{name: name, w: os.Stdout}, nil
+}
+
+func (s *stdoutSink) Name() string { return s.name }
+
+// IsStdout reports whether s prints to stdout, which a full-screen UI
+// can't share
+func IsStdout(s Sink) bool {
+	_, ok := s.(*stdoutSink)
+	return ok
+}
+
+func (s *stdoutSink) Send(ctx context.Context, r Result) (string, error) {
+	content := r.Content
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if _, err := io.WriteString(s.w, content); err != nil {
+		return "", err
+	}
+	return "stdout", nil
+}
+
+// webhookSink posts the result to a URL: as {"title", "content"} JSON,
+// as a Slack-style {"text"} message, or as the plain markdown
+type webhookSink struct {
+	name       string
+	cfg        config.OutputConfig
+	httpClient *http.Client
+}
+
+func newWebhookSink(name string, cfg config.OutputConfig) (Sink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("webhook output %q needs a url", name)
+	}
+	switch cfg.Format {
+	case "", "json", "slack", "text":
+	default:
+		return nil, fmt.Errorf("webhook output %q: unknown format %q (json, slack, text)", name, cfg.Format)
+	}
+	return &webhookSink{
+		name:       name,
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (s *webhookSink) Name() string { return s.name }
+
+func (s *webhookSink) Send(ctx context.Context, r Result) (string, error) {
+	var body []byte
+	contentType := "application/json"
+	switch s.cfg.Format {
+	case "slack":
+		text := r.Content
+		if r.Title != "" {
+			text = "*" + r.Title + "*\n\n" + text
+		}
+		body, _ = json.Marshal(map[string]string{"text": text})
+	case "text":
+		body = []byte(r.Content)
+		contentType = "text/markdown; charset=utf-8"
+	default:
+		body, _ = json.Marshal(map[string]string{"title": r.Title, "content": r.Content})
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range s.cfg.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("webhook %s: %w", s.name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("webhook %s: status %d: %s", s.name, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return s.cfg.URL, nil
+}
+
+// commandSink pipes the result into an external program, for tools
+// with a CLI (note apps, task trackers); {title} in args is substituted
+type commandSink struct {
+	name string
+	cfg  config.OutputConfig
+}
+
+func newCommandSink(name string, cfg config.OutputConfig) (Sink, error) {
+	if cfg.Command == "" {
+		return nil, fmt.Errorf("command output %q needs a command", name)
+	}
+	return &commandSink{name: name, cfg: cfg}, nil
+}
+
+func (s *commandSink) Name() string { return s.name }
+
+func (s *commandSink) Send(ctx context.Context, r Result) (string, error) {
+	args := make([]string, len(s.cfg.Args))
+	for i, a := range s.cfg.Args {
+		args[i] = strings.ReplaceAll(a, "{title}", r.Title)
+	}
+
+	cmd := exec.CommandContext(ctx, s.cfg.Command, args...)
+	cmd.Stdin = strings.NewReader(r.Content)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("%s: %s", s.cfg.Command, msg)
+	}
+	return s.cfg.Command, nil
+}
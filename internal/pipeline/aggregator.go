@@ -11,6 +11,13 @@ type AggregatedContent struct {
 	Facts     []string
 	Summaries []string
 	WordCount int
+
+	// Overview of the whole document, rolled up from the section
+	// summaries in hierarchical mode
+	Overview string `json:",omitempty"`
+
+	// Footnotes referenced anywhere in the document, for citing
+	Footnotes []Footnote `json:",omitempty"`
 }
 
 // Aggregate combines extractions from all chunks
@@ -68,6 +75,10 @@ func Aggregate(extractions []*Extraction) *AggregatedContent {
 func (a *AggregatedContent) FormatForWriter() string {
 	var b strings.Builder
 
+	if a.Overview != "" {
+		b.WriteString("DOCUMENT OVERVIEW:\n" + a.Overview + "\n\n")
+	}
+
 	if len(a.Summaries) > 0 {
 		b.WriteString("SECTION SUMMARIES:\n")
 		for _, s := range a.Summaries {
@@ -96,5 +107,10 @@ func (a *AggregatedContent) FormatForWriter() string {
 		b.WriteString("KEY ENTITIES: " + strings.Join(a.Entities, ", ") + "\n")
 	}
 
+	if len(a.Footnotes) > 0 {
+		b.WriteString("\nFOOTNOTES (cite as [^n] where a point relies on one, or inline the note where it qualifies the point):\n")
+		b.WriteString(FormatFootnotes(a.Footnotes) + "\n")
+	}
+
 	return b.String()
 }
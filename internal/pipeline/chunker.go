@@ -11,6 +11,10 @@ type Chunk struct {
 	Content  string
 	Section  string
 	Position int
+
+	// Footnotes referenced by Content; their definitions are removed
+	// from the chunked text
+	Footnotes []Footnote
 }
 
 // ChunkDocument splits document into semantic chunks
@@ -21,6 +25,9 @@ func ChunkDocument(content string, maxChunkSize int) []Chunk {
 
 	var chunks []Chunk
 
+	// Footnote definitions travel with the chunks that reference them
+	content, notes := ExtractFootnotes(content)
+
 	// Split by double newlines (paragraphs/sections)
 	sections := strings.Split(content, "\n\n")
 
@@ -75,6 +82,7 @@ func ChunkDocument(content string, maxChunkSize int) []Chunk {
 		})
 	}
 
+	attachFootnotes(chunks, notes)
 	return chunks
 }
 
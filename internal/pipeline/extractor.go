@@ -24,6 +24,7 @@ type Extraction struct {
 type Extractor struct {
 	provider llm.Provider
 	model    string
+	language string // See prompts.BuildExtractionPrompt
 }
 
 func NewExtractor(provider llm.Provider, model string) *Extractor {
@@ -33,21 +34,29 @@ func NewExtractor(provider llm.Provider, model string) *Extractor {
 	}
 }
 
-func (e *Extractor) Extract(ctx context.Context, chunk Chunk) (*Extraction, error) {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
-
-	req := &llm.CompletionRequest{
+// Request builds the extraction request for a chunk
+func (e *Extractor) Request(chunk Chunk) *llm.CompletionRequest {
+	content := chunk.Content
+	if len(chunk.Footnotes) > 0 {
+		content += "\n\nFootnotes referenced above:\n" + FormatFootnotes(chunk.Footnotes)
+	}
+	return &llm.CompletionRequest{
 		Model: e.model,
 		Messages: []llm.Message{
-			{Role: "system", Content: prompts.Extraction},
-			{Role: "user", Content: chunk.Content},
+			{Role: "system", Content: prompts.BuildExtractionPrompt(e.language)},
+			{Role: "user", Content: content},
 		},
 		MaxTokens:   500,
 		Temperature: 0.3,
+		JSON:        llm.CapabilitiesOf(e.provider).JSONMode,
 	}
+}
+
+func (e *Extractor) Extract(ctx context.Context, chunk Chunk) (*Extraction, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
 
-	resp, err := e.provider.Complete(ctx, req)
+	resp, err := e.provider.Complete(ctx, e.Request(chunk))
 	if err != nil {
 		return nil, fmt.Errorf("extraction failed: %w", err)
 	}
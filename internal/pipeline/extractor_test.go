@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/intent"
+	"github.com/sant0-9/pulp/internal/prompts"
+)
+
+func TestExtractionLanguage(t *testing.T) {
+	p := NewPipeline(nil, "model")
+	system := func() string { return p.extractor.Request(Chunk{Content: "Der Umsatz stieg."}).Messages[0].Content }
+
+	if system() != prompts.Extraction {
+		t.Error("default extraction prompt changed")
+	}
+
+	p.SetLanguage("document")
+	if !strings.Contains(system(), "same language as the text") {
+		t.Errorf("document language rule missing:\n%s", system())
+	}
+
+	p.SetLanguage("French")
+	if !strings.Contains(system(), "in French") {
+		t.Errorf("target language rule missing:\n%s", system())
+	}
+
+	for prompt, want := range map[string]string{
+		"summarize in the original language": "document",
+		"key points, don't translate":        "document",
+		"summarize":                          "",
+	} {
+		if got := intent.New(prompt).ExtractionLanguage; got != want {
+			t.Errorf("intent.New(%q).ExtractionLanguage = %q, want %q", prompt, got, want)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Footnote is a footnote or endnote definition from the document
+type Footnote struct {
+	Label string // "1", "a", ... as referenced in the text
+	Text  string
+}
+
+var (
+	// Markdown footnote definition: [^1]: text
+	footnoteDefPattern = regexp.MustCompile(`^\[\^([^\]\s]+)\]:\s*(.*)$`)
+
+	// Heading that starts an endnote list
+	endnotesHeadingPattern = regexp.MustCompile(`(?i)^(#{1,6})\s+(foot ?notes|end ?notes|notes)\s*:?\s*$`)
+
+	// Endnote entry: "1. text", "1) text" or "[1] text"
+	endnoteEntryPattern = regexp.MustCompile(`^(?:\[(\d+)\]|(\d+)[.)])\s+(.*)$`)
+
+	// References in the text: [^1], [1] or superscript digits
+	footnoteRefPattern = regexp.MustCompile(`\[\^([^\]\s]+)\]|\[(\d+)\]|([⁰¹²³⁴⁵⁶⁷⁸⁹]+)`)
+)
+
+var superscriptDigits = strings.NewReplacer(
+	"⁰", "0", "¹", "1", "²", "2", "³", "3", "⁴", "4",
+	"⁵", "5", "⁶", "6", "⁷", "7", "⁸", "8", "⁹", "9",
+)
+
+// ExtractFootnotes removes footnote definitions (markdown [^n]: lines and
+// trailing Footnotes/Endnotes/Notes lists) from content and returns them
+// by label. A notes list is only treated as endnotes when it ends the
+// document and the text references at least one of its entries, so
+// ordinary "Notes" sections are left alone.
+func ExtractFootnotes(content string) (string, map[string]Footnote) {
+	notes := make(map[string]Footnote)
+	lines := strings.Split(content, "\n")
+
+	// Markdown definitions, with indented continuation lines
+	var body []string
+	var last string
+	for _, line := range lines {
+		if m := footnoteDefPattern.FindStringSubmatch(line); m != nil {
+			last = m[1]
+			notes[last] = Footnote{Label: last, Text: strings.TrimSpace(m[2])}
+			continue
+		}
+		if last != "" && (strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")) {
+			n := notes[last]
+			n.Text = strings.TrimSpace(n.Text + " " + strings.TrimSpace(line))
+			notes[last] = n
+			continue
+		}
+		last = ""
+		body = append(body, line)
+	}
+
+	body = extractEndnotes(body, notes)
+	return strings.Join(body, "\n"), notes
+}
+
+// extractEndnotes removes endnote sections from the end of lines, adding
+// their entries to notes
+func extractEndnotes(lines []string, notes map[string]Footnote) []string {
+	for start := len(lines) - 1; start >= 0; start-- {
+		h := endnotesHeadingPattern.FindStringSubmatch(strings.TrimSpace(lines[start]))
+		if h == nil {
+			continue
+		}
+
+		// Section runs to the next heading of the same or higher level
+		end := len(lines)
+		for i := start + 1; i < len(lines); i++ {
+			if m := headingPattern.FindStringSubmatch(strings.TrimSpace(lines[i])); m != nil && len(m[1]) <= len(h[1]) {
+				end = i
+				break
+			}
+		}
+		if !blank(lines[end:]) {
+			continue // Not at the end of the document
+		}
+
+		entries := make(map[string]Footnote)
+		var order []string
+		for _, line := range lines[start+1 : end] {
+			line = strings.TrimSpace(line)
+			if m := endnoteEntryPattern.FindStringSubmatch(line); m != nil {
+				label := m[1] + m[2]
+				entries[label] = Footnote{Label: label, Text: m[3]}
+				order = append(order, label)
+			} else if line != "" && len(order) > 0 {
+				n := entries[order[len(order)-1]]
+				n.Text += " " + line
+				entries[order[len(order)-1]] = n
+			}
+		}
+
+		rest := append(append([]string{}, lines[:start]...), lines[end:]...)
+		referenced := footnoteRefs(strings.Join(rest, "\n"))
+		isEndnotes := false
+		for _, label := range order {
+			if referenced[label] {
+				isEndnotes = true
+				break
+			}
+		}
+		if !isEndnotes {
+			continue
+		}
+
+		for label, n := range entries {
+			if _, ok := notes[label]; !ok {
+				notes[label] = n
+			}
+		}
+		lines = rest
+	}
+	return lines
+}
+
+// blank reports whether lines are all empty or whitespace
+func blank(lines []string) bool {
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// footnoteRefs returns the labels referenced in text
+func footnoteRefs(text string) map[string]bool {
+	refs := make(map[string]bool)
+	for _, label := range footnoteRefList(text) {
+		refs[label] = true
+	}
+	return refs
+}
+
+// footnoteRefList returns the labels referenced in text, in order of
+// first reference
+func footnoteRefList(text string) []string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, loc := range footnoteRefPattern.FindAllStringSubmatchIndex(text, -1) {
+		var label string
+		switch {
+		case loc[2] >= 0:
+			label = text[loc[2]:loc[3]]
+		case loc[4] >= 0:
+			// [1](url) is a link and [1]: a definition, not references
+			if loc[1] < len(text) && (text[loc[1]] == '(' || text[loc[1]] == ':') {
+				continue
+			}
+			label = text[loc[4]:loc[5]]
+		default:
+			if !superscriptRef(text, loc[6], loc[7]) {
+				continue
+			}
+			label = superscriptDigits.Replace(text[loc[6]:loc[7]])
+		}
+		if !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// superscriptRef reports whether the superscript digits at text[start:end]
+// mark a note rather than a power: they follow a word or punctuation, and
+// not a unit or variable such as m² or x², and don't run into the next word
+func superscriptRef(text string, start, end int) bool {
+	prev, _ := utf8.DecodeLastRuneInString(text[:start])
+	if start == 0 || !(unicode.IsLetter(prev) || unicode.IsPunct(prev)) {
+		return false
+	}
+	if next, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && (unicode.IsLetter(next) || unicode.IsDigit(next)) {
+		return false
+	}
+	if unicode.IsLetter(prev) {
+		word := strings.TrimRightFunc(text[:start], unicode.IsLetter)
+		if utf8.RuneCountInString(text[len(word):start]) <= 2 {
+			return false // m², km², ft², x²
+		}
+	}
+	return true
+}
+
+// attachFootnotes gives each chunk the notes its text references
+func attachFootnotes(chunks []Chunk, notes map[string]Footnote) {
+	if len(notes) == 0 {
+		return
+	}
+	for i := range chunks {
+		for _, label := range footnoteRefList(chunks[i].Content) {
+			if n, ok := notes[label]; ok {
+				chunks[i].Footnotes = append(chunks[i].Footnotes, n)
+			}
+		}
+	}
+}
+
+// CollectFootnotes returns the notes referenced by any chunk, in order
+// of first reference
+func CollectFootnotes(chunks []Chunk) []Footnote {
+	var notes []Footnote
+	seen := make(map[string]bool)
+	for _, c := range chunks {
+		for _, n := range c.Footnotes {
+			if !seen[n.Label] {
+				seen[n.Label] = true
+				notes = append(notes, n)
+			}
+		}
+	}
+	return notes
+}
+
+// FormatFootnotes renders notes as markdown footnote definitions
+func FormatFootnotes(notes []Footnote) string {
+	var b strings.Builder
+	for i, n := range notes {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(fmt.Sprintf("[^%s]: %s", n.Label, n.Text))
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChunkDocumentAttachesFootnotes(t *testing.T) {
+	content := "# Results\n\nChurn fell to 4%[^churn] while revenue grew 12%.[^1]\n\n" +
+		"# Outlook\n\nGuidance is unchanged.\n\n" +
+		"[^1]: Constant currency.\n[^churn]: Excludes the legacy\n    reseller plan."
+
+	chunks := ChunkDocument(content, 60)
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+	for _, c := range chunks {
+		if strings.Contains(c.Content, "Constant currency") {
+			t.Errorf("definition left in chunk text: %q", c.Content)
+		}
+	}
+
+	want := []Footnote{{"churn", "Excludes the legacy reseller plan."}, {"1", "Constant currency."}}
+	got := chunks[0].Footnotes
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("chunk 0 footnotes = %+v, want %+v", got, want)
+	}
+	if len(chunks[1].Footnotes) != 0 {
+		t.Errorf("chunk 1 footnotes = %+v, want none", chunks[1].Footnotes)
+	}
+}
+
+func TestExtractEndnotes(t *testing.T) {
+	content := "Sales rose in Q3.² See [1](https://example.com).\n\n## Notes\n\n1. Unused note.\n2. Includes the\nAPAC acquisition."
+
+	body, notes := ExtractFootnotes(content)
+	if strings.Contains(body, "Notes") {
+		t.Errorf("endnotes section left in body: %q", body)
+	}
+	if notes["2"].Text != "Includes the APAC acquisition." {
+		t.Errorf("notes = %+v", notes)
+	}
+
+	// A notes list nothing refers to is ordinary content
+	meeting := "Team sync.\n\n## Notes\n\n1. Budget approved.\n2. Hiring paused."
+	if body, notes := ExtractFootnotes(meeting); body != meeting || len(notes) != 0 {
+		t.Errorf("ExtractFootnotes() changed unreferenced notes: %q, %+v", body, notes)
+	}
+
+	// Powers and units aren't references, and notes mid-document aren't endnotes
+	for _, doc := range []string{
+		"The site covers 40 m² and the model fits x² + y².\n\n## Notes\n\n1. Surveyed in May.\n2. Fit by hand.",
+		"Sales rose in Q3.²\n\n## Notes\n\n1. Budget approved.\n2. Hiring paused.\n\n## Next steps\n\nShip it.",
+	} {
+		if body, notes := ExtractFootnotes(doc); body != doc || len(notes) != 0 {
+			t.Errorf("ExtractFootnotes() = %q, %+v, want the document unchanged", body, notes)
+		}
+	}
+	if got := footnoteRefList("Revenue grew.¹ Costs fell²; area 12 km², E = mc²"); strings.Join(got, ",") != "1,2" {
+		t.Errorf("footnoteRefList() = %q, want [1 2]", got)
+	}
+}
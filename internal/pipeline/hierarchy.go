@@ -0,0 +1,214 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sant0-9/pulp/internal/llm"
+	"github.com/sant0-9/pulp/internal/prompts"
+)
+
+// sectionGroup is a top-level section and the chunks it was split into
+type sectionGroup struct {
+	title  string
+	chunks []int // Indexes into the document's chunks
+}
+
+// sectionChunks splits a document into large chunks that never cross a
+// top-level section, and groups them by section
+func sectionChunks(content string) ([]Chunk, []sectionGroup) {
+	var chunks []Chunk
+	var groups []sectionGroup
+
+	for _, s := range BuildOutline(content).TopLevel() {
+		g := sectionGroup{title: s.Title}
+		for _, c := range s.Chunks(HierarchicalChunkSize) {
+			c.ID = len(chunks)
+			c.Position = len(chunks)
+			g.chunks = append(g.chunks, len(chunks))
+			chunks = append(chunks, c)
+		}
+		if len(g.chunks) > 0 {
+			groups = append(groups, g)
+		}
+	}
+
+	return chunks, groups
+}
+
+// summaryCalls returns how many calls summarizeSections makes on top of
+// extraction: one per section with more than one chunk, and a roll-up
+// when there are several sections
+func summaryCalls(groups []sectionGroup) int {
+	n := 0
+	for _, g := range groups {
+		if len(g.chunks) > 1 {
+			n++
+		}
+	}
+	if len(groups) > 1 {
+		n++
+	}
+	return n
+}
+
+// labelSection prefixes a summary with its section title
+func labelSection(title, summary string) string {
+	if title == "" {
+		return summary
+	}
+	return fmt.Sprintf("[%s] %s", title, summary)
+}
+
+// SectionSummaryRequest builds the request that summarizes a section
+// from the extractions of its chunks
+func (e *Extractor) SectionSummaryRequest(title string, exts []*Extraction) *llm.CompletionRequest {
+	var b strings.Builder
+	if title != "" {
+		b.WriteString("Section: " + title + "\n")
+	}
+	for i, ext := range exts {
+		b.WriteString(fmt.Sprintf("\nPart %d:\n", i+1))
+		if ext.Summary != "" {
+			b.WriteString("Summary: " + ext.Summary + "\n")
+		}
+		for _, kp := range ext.KeyPoints {
+			b.WriteString("- " + kp + "\n")
+		}
+		for _, f := range ext.Facts {
+			b.WriteString("- " + f + "\n")
+		}
+	}
+
+	return &llm.CompletionRequest{
+		Model: e.model,
+		Messages: []llm.Message{
+			{Role: "system", Content: prompts.BuildSummaryPrompt(prompts.SectionSummary, e.language)},
+			{Role: "user", Content: strings.TrimSpace(b.String())},
+		},
+		MaxTokens:   400,
+		Temperature: 0.3,
+	}
+}
+
+// RollupRequest builds the request that combines section summaries into
+// an overview of the document
+func (e *Extractor) RollupRequest(summaries []string) *llm.CompletionRequest {
+	return &llm.CompletionRequest{
+		Model: e.model,
+		Messages: []llm.Message{
+			{Role: "system", Content: prompts.BuildSummaryPrompt(prompts.DocumentRollup, e.language)},
+			{Role: "user", Content: strings.Join(summaries, "\n\n")},
+		},
+		MaxTokens:   500,
+		Temperature: 0.3,
+	}
+}
+
+// Summarize sends a section summary or roll-up request and returns the
+// text of the reply
+func (e *Extractor) Summarize(ctx context.Context, req *llm.CompletionRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	resp, err := e.provider.Complete(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("summary failed: %w", err)
+	}
+	return strings.TrimSpace(resp.Content), nil
+}
+
+// summarizeSections summarizes each section from the extractions of its
+// chunks, then rolls the section summaries up into an overview of the
+// document. A section made of one chunk keeps that chunk's summary; a
+// section whose summary call fails keeps its chunk summaries.
+func (p *Pipeline) summarizeSections(ctx context.Context, groups []sectionGroup, results []*Extraction) (summaries []string, overview string) {
+	perSection := make([][]string, len(groups))
+	var mu sync.Mutex
+	done := 0
+
+	p.forEach(ctx, len(groups), func(i int) {
+		g := groups[i]
+		var exts []*Extraction
+		for _, c := range g.chunks {
+			if results[c] != nil {
+				exts = append(exts, results[c])
+			}
+		}
+
+		var summary string
+		if len(exts) > 1 {
+			summary, _ = p.extractor.Summarize(ctx, p.extractor.SectionSummaryRequest(g.title, exts))
+		} else if len(exts) == 1 {
+			summary = exts[0].Summary
+		}
+
+		if summary != "" {
+			perSection[i] = []string{labelSection(g.title, summary)}
+		} else {
+			for _, ext := range exts {
+				if ext.Summary != "" {
+					perSection[i] = append(perSection[i], labelSection(g.title, ext.Summary))
+				}
+			}
+		}
+
+		mu.Lock()
+		done++
+		p.progress(Progress{
+			Stage:       StageAggregating,
+			StageIndex:  2,
+			TotalStages: 3,
+			ItemIndex:   done,
+			TotalItems:  len(groups),
+			Message:     fmt.Sprintf("Summarizing section %d/%d", done, len(groups)),
+		})
+		mu.Unlock()
+	})
+
+	for _, s := range perSection {
+		summaries = append(summaries, s...)
+	}
+
+	if len(groups) > 1 && len(summaries) > 1 && ctx.Err() == nil {
+		p.progress(Progress{
+			Stage:       StageAggregating,
+			StageIndex:  2,
+			TotalStages: 3,
+			Message:     "Combining section summaries...",
+		})
+		overview, _ = p.extractor.Summarize(ctx, p.extractor.RollupRequest(summaries))
+	}
+
+	return summaries, overview
+}
+
+// forEach calls fn for 0..n-1 on as many workers as the provider allows
+// in flight, and stops handing out work once ctx is done
+func (p *Pipeline) forEach(ctx context.Context, n int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < min(p.workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,68 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/llm"
+	"github.com/sant0-9/pulp/internal/prompts"
+)
+
+// stubProvider answers extraction, section summary and roll-up requests
+type stubProvider struct{}
+
+func (stubProvider) Name() string                   { return "stub" }
+func (stubProvider) Ping(ctx context.Context) error { return nil }
+func (stubProvider) Stream(ctx context.Context, req *llm.CompletionRequest) (<-chan llm.StreamEvent, error) {
+	return nil, nil
+}
+func (stubProvider) Complete(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	switch req.Messages[0].Content {
+	case prompts.SectionSummary:
+		return &llm.CompletionResponse{Content: "section summary"}, nil
+	case prompts.DocumentRollup:
+		return &llm.CompletionResponse{Content: "document overview"}, nil
+	}
+	return &llm.CompletionResponse{Content: `{"key_points":["point"],"summary":"chunk summary"}`}, nil
+}
+
+func TestHierarchicalProcess(t *testing.T) {
+	long := strings.Repeat(strings.Repeat("word ", 200)+"\n\n", 8) // Two large chunks
+	content := "# Report\n\nIntro text.\n\n## Background\n\n" + long + "## Findings\n\n### Detail\n\nShort section."
+
+	chunks, groups := sectionChunks(content)
+	if len(groups) != 3 || groups[0].title != "" || groups[1].title != "Background" || groups[2].title != "Findings" {
+		t.Fatalf("sectionChunks() groups = %+v", groups)
+	}
+	if len(groups[1].chunks) != 2 || len(chunks) != 4 || chunks[3].ID != 3 {
+		t.Fatalf("sectionChunks() = %d chunks, groups %+v", len(chunks), groups)
+	}
+	// 4 extractions, 1 section summary (Background), 1 roll-up
+	if got := EstimateCalls(content, true); got != 6 {
+		t.Errorf("EstimateCalls() = %d, want 6", got)
+	}
+
+	p := NewPipeline(stubProvider{}, "model")
+	p.SetHierarchical(true)
+
+	if calls := p.Plan(&converter.Document{Content: content}).Calls; len(calls) != 6 || calls[4].Stage != "section summary" || calls[5].Stage != "roll-up" {
+		t.Errorf("Plan() calls = %+v", calls)
+	}
+
+	res, err := p.Process(context.Background(), &converter.Document{Content: content}, nil)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	want := []string{"chunk summary", "[Background] section summary", "[Findings] chunk summary"}
+	if strings.Join(res.Aggregated.Summaries, "|") != strings.Join(want, "|") {
+		t.Errorf("Summaries = %q, want %q", res.Aggregated.Summaries, want)
+	}
+	if res.Aggregated.Overview != "document overview" {
+		t.Errorf("Overview = %q", res.Aggregated.Overview)
+	}
+	if !strings.HasPrefix(res.Aggregated.FormatForWriter(), "DOCUMENT OVERVIEW:\ndocument overview") {
+		t.Errorf("FormatForWriter() doesn't lead with the overview:\n%s", res.Aggregated.FormatForWriter())
+	}
+}
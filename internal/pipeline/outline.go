@@ -0,0 +1,250 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Section is a heading in the document outline with the text under it,
+// including its subsections
+type Section struct {
+	Number  string // Outline number, e.g. "3" or "3.2"; empty for a document title
+	Title   string
+	Level   int // Markdown heading level (1 = #)
+	Content string
+
+	notes map[string]Footnote // Document footnotes, for Chunks
+}
+
+// Label returns the section number and title for display
+func (s *Section) Label() string {
+	if s.Number == "" {
+		return s.Title
+	}
+	return fmt.Sprintf("%s. %s", s.Number, s.Title)
+}
+
+// Chunks splits the section content into chunks for the writer
+func (s *Section) Chunks(maxChunkSize int) []Chunk {
+	chunks := ChunkDocument(s.Content, maxChunkSize)
+	for i := range chunks {
+		chunks[i].Section = s.Title
+	}
+	attachFootnotes(chunks, s.notes)
+	return chunks
+}
+
+// Outline is the ordered list of document sections
+type Outline struct {
+	Sections []*Section
+
+	preface *Section // Text before the first top-level section, if any
+}
+
+var (
+	headingPattern    = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	sectionRefPattern = regexp.MustCompile(`(?i)(?:\bsection|\bsec\.?|§)\s*(\d+(?:\.\d+)*)`)
+	leadingNumPattern = regexp.MustCompile(`^(?i:section\s+)?(\d+(?:\.\d+)*)[.):]?\s+`)
+)
+
+// BuildOutline parses markdown headings into a numbered outline.
+// The shallowest heading level present is numbered 1..n, deeper
+// levels are numbered relative to their parent (3.1, 3.2, ...). A lone
+// top-level heading is the document title: it stays unnumbered and
+// numbering starts at the level below it.
+func BuildOutline(content string) *Outline {
+	outline := &Outline{}
+
+	// Footnote definitions aren't sections of their own
+	content, notes := ExtractFootnotes(content)
+
+	lines := strings.Split(content, "\n")
+	var starts []int // Line index of each section's heading
+	inFence := false
+
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if inFence {
+			continue
+		}
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			outline.Sections = append(outline.Sections, &Section{
+				Title: strings.TrimSpace(m[2]),
+				Level: len(m[1]),
+				notes: notes,
+			})
+			starts = append(starts, i)
+		}
+	}
+
+	// A section runs until the next heading at the same or a higher
+	// level, so "section 3" carries 3.1, 3.2, ... with it
+	for i, s := range outline.Sections {
+		end := len(lines)
+		for j := i + 1; j < len(outline.Sections); j++ {
+			if outline.Sections[j].Level <= s.Level {
+				end = starts[j]
+				break
+			}
+		}
+		s.Content = strings.TrimSpace(strings.Join(lines[starts[i]+1:end], "\n"))
+	}
+
+	outline.number()
+
+	// Text ahead of section 1, less the title heading, belongs to none
+	end := len(lines)
+	for i, s := range outline.Sections {
+		if s.Number == "1" {
+			end = starts[i]
+			break
+		}
+	}
+	var before []string
+	for i, line := range lines[:end] {
+		if len(starts) > 0 && i == starts[0] && outline.Sections[0].Number == "" {
+			continue
+		}
+		before = append(before, line)
+	}
+	if text := strings.TrimSpace(strings.Join(before, "\n")); text != "" {
+		outline.preface = &Section{Content: text, notes: notes}
+	}
+
+	return outline
+}
+
+// TopLevel returns the document split into its top-level sections, each
+// with its subsections, after an untitled section holding any text that
+// comes before them. A document without headings is one untitled
+// section.
+func (o *Outline) TopLevel() []*Section {
+	var top []*Section
+	if o.preface != nil {
+		top = append(top, o.preface)
+	}
+	for _, s := range o.Sections {
+		if s.Number != "" && !strings.Contains(s.Number, ".") {
+			top = append(top, s)
+		}
+	}
+	return top
+}
+
+// number assigns hierarchical numbers based on heading levels
+func (o *Outline) number() {
+	if len(o.Sections) == 0 {
+		return
+	}
+
+	// A first heading shallower than all the rest is the title, not
+	// section 1
+	sections := o.Sections
+	if len(sections) > 1 && sections[0].Level < topLevel(sections[1:]) {
+		sections = sections[1:]
+	}
+	minLevel := topLevel(sections)
+
+	var counters []int
+	for _, s := range sections {
+		depth := s.Level - minLevel
+		for len(counters) <= depth {
+			counters = append(counters, 0)
+		}
+		counters = counters[:depth+1]
+		counters[depth]++
+
+		parts := make([]string, len(counters))
+		for i, c := range counters {
+			parts[i] = strconv.Itoa(c)
+		}
+		s.Number = strings.Join(parts, ".")
+	}
+}
+
+// topLevel returns the shallowest heading level among sections
+func topLevel(sections []*Section) int {
+	level := sections[0].Level
+	for _, s := range sections {
+		if s.Level < level {
+			level = s.Level
+		}
+	}
+	return level
+}
+
+// byNumber finds a section by number: headings that carry their own
+// number ("3. Results") first, then outline numbering
+func (o *Outline) byNumber(num string) *Section {
+	for _, s := range o.Sections {
+		if n := leadingNumPattern.FindStringSubmatch(s.Title); n != nil && n[1] == num {
+			return s
+		}
+	}
+	for _, s := range o.Sections {
+		if s.Number == num {
+			return s
+		}
+	}
+	return nil
+}
+
+// SectionRef is a numbered section reference found in text
+type SectionRef struct {
+	Start, End int // Byte offsets of the reference, e.g. "section 3"
+	Section    *Section
+}
+
+// FindSectionRefs returns the references in text to sections by number
+// ("section 3", "sec. 2.1", "§4") that name a section in the outline
+func (o *Outline) FindSectionRefs(text string) []SectionRef {
+	if o == nil || len(o.Sections) == 0 {
+		return nil
+	}
+
+	var refs []SectionRef
+	for _, m := range sectionRefPattern.FindAllStringSubmatchIndex(text, -1) {
+		if s := o.byNumber(text[m[2]:m[3]]); s != nil {
+			refs = append(refs, SectionRef{Start: m[0], End: m[1], Section: s})
+		}
+	}
+	return refs
+}
+
+// Resolve finds the section an instruction refers to, e.g.
+// "expand on section 3" or "rewrite the Risk Factors section".
+// Returns nil if the instruction doesn't reference a section.
+func (o *Outline) Resolve(instruction string) *Section {
+	if o == nil || len(o.Sections) == 0 {
+		return nil
+	}
+
+	if m := sectionRefPattern.FindStringSubmatch(instruction); m != nil {
+		return o.byNumber(m[1])
+	}
+
+	// Title reference: only when the instruction mentions a section
+	lower := strings.ToLower(instruction)
+	if !strings.Contains(lower, "section") {
+		return nil
+	}
+
+	var best *Section
+	bestLen := 0
+	for _, s := range o.Sections {
+		title := strings.ToLower(leadingNumPattern.ReplaceAllString(s.Title, ""))
+		if len(title) < 3 || !strings.Contains(lower, title) {
+			continue
+		}
+		// Longest match wins ("Market Risk" over "Risk")
+		if len(title) > bestLen {
+			best = s
+			bestLen = len(title)
+		}
+	}
+	return best
+}
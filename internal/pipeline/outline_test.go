@@ -0,0 +1,111 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+const outlineDoc = `# Annual Report
+
+Intro text.
+
+## Overview
+Overview text.
+
+## Financial Results
+Revenue grew.
+
+### Market Risk
+Rates rose.
+
+## 7. Outlook
+Next year looks good.
+`
+
+func TestBuildOutline(t *testing.T) {
+	outline := BuildOutline(outlineDoc)
+
+	want := []struct {
+		number string
+		title  string
+	}{
+		{"", "Annual Report"},
+		{"1", "Overview"},
+		{"2", "Financial Results"},
+		{"2.1", "Market Risk"},
+		{"3", "7. Outlook"},
+	}
+
+	if len(outline.Sections) != len(want) {
+		t.Fatalf("BuildOutline() returned %d sections, want %d", len(outline.Sections), len(want))
+	}
+	for i, w := range want {
+		s := outline.Sections[i]
+		if s.Number != w.number || s.Title != w.title {
+			t.Errorf("section %d = %s %q, want %s %q", i, s.Number, s.Title, w.number, w.title)
+		}
+	}
+
+	withSub := "Revenue grew.\n\n### Market Risk\nRates rose."
+	if got := outline.Sections[2].Content; got != withSub {
+		t.Errorf("section content = %q, want %q", got, withSub)
+	}
+	if got := outline.Sections[3].Content; got != "Rates rose." {
+		t.Errorf("subsection content = %q, want %q", got, "Rates rose.")
+	}
+}
+
+func TestBuildOutlineWithoutTitle(t *testing.T) {
+	outline := BuildOutline("# Intro\nHi.\n\n## Scope\nAll of it.\n\n# Method\nCareful.\n")
+
+	var got []string
+	for _, s := range outline.Sections {
+		got = append(got, s.Label())
+	}
+	want := "1. Intro, 1.1. Scope, 2. Method"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("labels = %q, want %q", strings.Join(got, ", "), want)
+	}
+}
+
+func TestOutlineResolve(t *testing.T) {
+	outline := BuildOutline(outlineDoc)
+
+	tests := []struct {
+		instruction string
+		want        string
+	}{
+		{"expand on section 2", "Financial Results"},
+		{"more detail on §2.1 please", "Market Risk"},
+		{"expand on section 7", "7. Outlook"},
+		{"rewrite the market risk section", "Market Risk"},
+		{"make it shorter", ""},
+		{"expand on section 42", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.instruction, func(t *testing.T) {
+			got := outline.Resolve(tt.instruction)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("Resolve() = %q, want nil", got.Title)
+				}
+				return
+			}
+			if got == nil || got.Title != tt.want {
+				t.Errorf("Resolve() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSectionChunks(t *testing.T) {
+	outline := BuildOutline("## Long\n\n" + strings.Repeat("word ", 100) + "\n\n" + strings.Repeat("word ", 100))
+	chunks := outline.Sections[0].Chunks(200)
+	if len(chunks) != 2 {
+		t.Fatalf("Chunks() returned %d chunks, want 2", len(chunks))
+	}
+	if chunks[0].Section != "Long" {
+		t.Errorf("chunk section = %q, want %q", chunks[0].Section, "Long")
+	}
+}
@@ -3,6 +3,7 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/sant0-9/pulp/internal/converter"
 	"github.com/sant0-9/pulp/internal/intent"
@@ -48,18 +49,31 @@ type Progress struct {
 type Result struct {
 	Aggregated *AggregatedContent
 	Chunks     []Chunk
+	Outline    *Outline
 }
 
+// Chunk sizes in characters. Hierarchical mode uses 4x bigger chunks so
+// huge documents need far fewer extraction calls.
+const (
+	DefaultChunkSize      = 1500
+	HierarchicalChunkSize = 6000
+)
+
 // Pipeline processes documents
 type Pipeline struct {
-	extractor  *Extractor
-	onProgress func(Progress)
+	extractor    *Extractor
+	onProgress   func(Progress)
+	hierarchical bool
+	workers      int // Concurrent extraction calls
+	canceler     llm.Canceler
 }
 
-// NewPipeline creates a new pipeline
+// NewPipeline creates a new pipeline. Extraction runs as many chunks at
+// once as the provider allows in flight (see llm.WithLimit).
 func NewPipeline(provider llm.Provider, model string) *Pipeline {
 	return &Pipeline{
 		extractor: NewExtractor(provider, model),
+		workers:   llm.MaxInFlight(provider),
 	}
 }
 
@@ -68,6 +82,46 @@ func (p *Pipeline) SetProgressCallback(fn func(Progress)) {
 	p.onProgress = fn
 }
 
+// SetLanguage sets the language extraction writes in: "document" keeps
+// the source language, a language name translates to it. An intent's
+// ExtractionLanguage overrides it for that run.
+func (p *Pipeline) SetLanguage(language string) {
+	p.extractor.language = language
+}
+
+// SetHierarchical switches to hierarchical mode for documents too big
+// for regular chunking: extraction runs on large chunks that stay within
+// one top-level section, each section is summarized from its chunks, and
+// the section summaries are rolled up into an overview of the document.
+func (p *Pipeline) SetHierarchical(enabled bool) {
+	p.hierarchical = enabled
+}
+
+// ChunkSize returns the chunk size for the pipeline mode
+func ChunkSize(hierarchical bool) int {
+	if hierarchical {
+		return HierarchicalChunkSize
+	}
+	return DefaultChunkSize
+}
+
+// EstimateCalls returns how many LLM calls processing a document needs,
+// counting section summaries and the roll-up in hierarchical mode
+func EstimateCalls(content string, hierarchical bool) int {
+	if hierarchical {
+		chunks, groups := sectionChunks(content)
+		return len(chunks) + summaryCalls(groups)
+	}
+	return len(ChunkDocument(content, DefaultChunkSize))
+}
+
+// Cancel stops a Process call in progress from another goroutine. It
+// aborts the extraction requests in flight and Process returns
+// context.Canceled.
+func (p *Pipeline) Cancel() {
+	p.canceler.Cancel()
+}
+
 func (p *Pipeline) progress(pr Progress) {
 	if p.onProgress != nil {
 		p.onProgress(pr)
@@ -75,7 +129,14 @@ func (p *Pipeline) progress(pr Progress) {
 }
 
 // Process runs the pipeline
-func (p *Pipeline) Process(ctx context.Context, doc *converter.Document, _ *intent.Intent) (*Result, error) {
+func (p *Pipeline) Process(ctx context.Context, doc *converter.Document, in *intent.Intent) (*Result, error) {
+	ctx, finish := p.canceler.Start(ctx)
+	defer finish()
+
+	if in != nil && in.ExtractionLanguage != "" {
+		p.extractor.language = in.ExtractionLanguage
+	}
+
 	// Stage 1: Chunking
 	p.progress(Progress{
 		Stage:       StageChunking,
@@ -84,7 +145,13 @@ func (p *Pipeline) Process(ctx context.Context, doc *converter.Document, _ *inte
 		Message:     "Splitting document into chunks...",
 	})
 
-	chunks := ChunkDocument(doc.Content, 1500)
+	var chunks []Chunk
+	var groups []sectionGroup
+	if p.hierarchical {
+		chunks, groups = sectionChunks(doc.Content)
+	} else {
+		chunks = ChunkDocument(doc.Content, DefaultChunkSize)
+	}
 	if len(chunks) == 0 {
 		return nil, fmt.Errorf("no content to process")
 	}
@@ -98,23 +165,41 @@ func (p *Pipeline) Process(ctx context.Context, doc *converter.Document, _ *inte
 		Message:     fmt.Sprintf("Extracting from %d chunks...", len(chunks)),
 	})
 
-	var extractions []*Extraction
-	for i, chunk := range chunks {
+	// Extract with as many workers as the provider allows in flight;
+	// results keep chunk order
+	results := make([]*Extraction, len(chunks))
+	var mu sync.Mutex
+	done := 0
+
+	p.forEach(ctx, len(chunks), func(i int) {
+		if ext, err := p.extractor.Extract(ctx, chunks[i]); err == nil {
+			results[i] = ext
+		}
+
+		mu.Lock()
+		done++
 		p.progress(Progress{
 			Stage:       StageExtracting,
 			StageIndex:  1,
 			TotalStages: 3,
-			ItemIndex:   i + 1,
+			ItemIndex:   done,
 			TotalItems:  len(chunks),
-			Message:     fmt.Sprintf("Extracting chunk %d/%d", i+1, len(chunks)),
+			Message:     fmt.Sprintf("Extracting chunk %d/%d", done, len(chunks)),
 		})
+		mu.Unlock()
+	})
+
+	// Canceled (or timed out): the extractions are incomplete
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-		ext, err := p.extractor.Extract(ctx, chunk)
-		if err != nil {
-			// Log but continue
-			continue
+	// Failed chunks are skipped
+	var extractions []*Extraction
+	for _, ext := range results {
+		if ext != nil {
+			extractions = append(extractions, ext)
 		}
-		extractions = append(extractions, ext)
 	}
 
 	// Stage 3: Aggregation
@@ -126,6 +211,15 @@ func (p *Pipeline) Process(ctx context.Context, doc *converter.Document, _ *inte
 	})
 
 	aggregated := Aggregate(extractions)
+	aggregated.Footnotes = CollectFootnotes(chunks)
+
+	// Hierarchical: section summaries replace the chunk summaries
+	if p.hierarchical {
+		aggregated.Summaries, aggregated.Overview = p.summarizeSections(ctx, groups, results)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
 
 	// Done
 	p.progress(Progress{
@@ -138,5 +232,6 @@ func (p *Pipeline) Process(ctx context.Context, doc *converter.Document, _ *inte
 	return &Result{
 		Aggregated: aggregated,
 		Chunks:     chunks,
+		Outline:    BuildOutline(doc.Content),
 	}, nil
 }
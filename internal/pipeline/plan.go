@@ -0,0 +1,136 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/llm"
+)
+
+// PlannedCall is one LLM request a run would make
+type PlannedCall struct {
+	Stage   string // "extraction", "write", ...
+	Label   string
+	Request *llm.CompletionRequest
+}
+
+// Plan describes a run without making any LLM calls (dry run)
+type Plan struct {
+	DocTitle  string
+	ChunkSize int
+	Chunks    []Chunk
+	Calls     []PlannedCall
+}
+
+// Plan chunks the document and builds the extraction prompts, stopping
+// before any LLM call is made. In hierarchical mode the section summary
+// and roll-up calls are included, with placeholders for the notes they
+// would be given.
+func (p *Pipeline) Plan(doc *converter.Document) *Plan {
+	size := ChunkSize(p.hierarchical)
+	var chunks []Chunk
+	var groups []sectionGroup
+	if p.hierarchical {
+		chunks, groups = sectionChunks(doc.Content)
+	} else {
+		chunks = ChunkDocument(doc.Content, size)
+	}
+
+	plan := &Plan{
+		DocTitle:  doc.Metadata.Title,
+		ChunkSize: size,
+		Chunks:    chunks,
+	}
+
+	for i, chunk := range chunks {
+		label := fmt.Sprintf("chunk %d/%d", i+1, len(chunks))
+		if chunk.Section != "" {
+			label += fmt.Sprintf(", section %q", chunk.Section)
+		}
+		plan.AddCall("extraction", label, p.extractor.Request(chunk))
+	}
+
+	var summaries []string
+	for _, g := range groups {
+		summaries = append(summaries, labelSection(g.title, "(section summary)"))
+		if len(g.chunks) < 2 {
+			continue
+		}
+		exts := make([]*Extraction, len(g.chunks))
+		for i, c := range g.chunks {
+			exts[i] = &Extraction{ChunkID: c, Summary: fmt.Sprintf("(notes from chunk %d)", c+1)}
+		}
+		label := "untitled section"
+		if g.title != "" {
+			label = fmt.Sprintf("section %q", g.title)
+		}
+		plan.AddCall("section summary", label, p.extractor.SectionSummaryRequest(g.title, exts))
+	}
+	if len(groups) > 1 {
+		plan.AddCall("roll-up", fmt.Sprintf("%d sections", len(groups)), p.extractor.RollupRequest(summaries))
+	}
+
+	return plan
+}
+
+// AddCall appends a later-stage request (e.g. the writer) to the plan
+func (p *Plan) AddCall(stage, label string, req *llm.CompletionRequest) {
+	p.Calls = append(p.Calls, PlannedCall{Stage: stage, Label: label, Request: req})
+}
+
+// EstimatedTokens returns the prompt tokens and the maximum output
+// tokens across all planned calls
+func (p *Plan) EstimatedTokens() (input, maxOutput int) {
+	for _, c := range p.Calls {
+		for _, m := range c.Request.Messages {
+			input += EstimateTokens(m.Content)
+		}
+		maxOutput += c.Request.MaxTokens
+	}
+	return input, maxOutput
+}
+
+// Summary returns the call and token counts
+func (p *Plan) Summary() string {
+	stages := make(map[string]int)
+	var order []string
+	for _, c := range p.Calls {
+		if stages[c.Stage] == 0 {
+			order = append(order, c.Stage)
+		}
+		stages[c.Stage]++
+	}
+
+	var parts []string
+	for _, s := range order {
+		parts = append(parts, fmt.Sprintf("%d %s", stages[s], s))
+	}
+
+	input, maxOutput := p.EstimatedTokens()
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Dry run: %s\n\n", p.DocTitle))
+	if len(p.Chunks) > 0 {
+		b.WriteString(fmt.Sprintf("Chunks:     %d (up to %d chars each)\n", len(p.Chunks), p.ChunkSize))
+	}
+	b.WriteString(fmt.Sprintf("LLM calls:  %d (%s)\n", len(p.Calls), strings.Join(parts, ", ")))
+	b.WriteString(fmt.Sprintf("Est tokens: ~%d input, up to %d output\n", input, maxOutput))
+	return b.String()
+}
+
+// Report returns the summary followed by every prompt that would be sent
+func (p *Plan) Report() string {
+	var b strings.Builder
+	b.WriteString(p.Summary())
+
+	for i, c := range p.Calls {
+		b.WriteString(fmt.Sprintf("\n=== Call %d/%d: %s (%s) ===\n", i+1, len(p.Calls), c.Stage, c.Label))
+		b.WriteString(fmt.Sprintf("model=%s max_tokens=%d temperature=%.1f\n", c.Request.Model, c.Request.MaxTokens, c.Request.Temperature))
+		for _, m := range c.Request.Messages {
+			b.WriteString(fmt.Sprintf("\n[%s]\n%s\n", m.Role, m.Content))
+		}
+	}
+
+	return b.String()
+}
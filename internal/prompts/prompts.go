@@ -12,6 +12,21 @@ var ChatBase string
 //go:embed extraction.md
 var Extraction string
 
+//go:embed chat_summary.md
+var ChatSummary string
+
+//go:embed batch_rollup.md
+var BatchRollup string
+
+//go:embed section_summary.md
+var SectionSummary string
+
+//go:embed document_rollup.md
+var DocumentRollup string
+
+//go:embed anonymize.md
+var Anonymize string
+
 // BuildChatPrompt constructs the full chat system prompt
 // If skill is provided, it appends the skill instructions
 func BuildChatPrompt(skillName, skillBody string) string {
@@ -28,6 +43,62 @@ func BuildChatPrompt(skillName, skillBody string) string {
 	return base
 }
 
+// WithPreamble puts the user's preamble from the config ahead of a
+// system prompt; an empty preamble returns the prompt unchanged
+func WithPreamble(preamble, prompt string) string {
+	preamble = strings.TrimSpace(preamble)
+	if preamble == "" {
+		return prompt
+	}
+	about := "About the user (apply these preferences to every response):\n\n" + preamble
+	if prompt == "" {
+		return about
+	}
+	return about + "\n\n---\n\n" + prompt
+}
+
+// BuildPinnedContext formats facts the user pinned in chat so they stay
+// in every prompt, however long the conversation gets
+func BuildPinnedContext(pinned []string) string {
+	if len(pinned) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("Pinned context (facts the user marked as important earlier in this conversation; treat them as established):\n")
+	for i, p := range pinned {
+		b.WriteString(fmt.Sprintf("\n%d. %s", i+1, strings.TrimSpace(p)))
+	}
+	return b.String()
+}
+
+// BuildExtractionPrompt returns the extraction prompt with a language
+// rule: "document" keeps the text's language, any other value is the
+// language to write in, and empty adds no rule
+func BuildExtractionPrompt(language string) string {
+	switch strings.ToLower(strings.TrimSpace(language)) {
+	case "":
+		return Extraction
+	case "document", "source", "original":
+		return strings.TrimSpace(Extraction) + "\nWrite every value in the same language as the text. Do not translate to English; keep names, terms and quotes as written."
+	default:
+		return strings.TrimSpace(Extraction) + fmt.Sprintf("\nWrite every value in %s, translating if the text is in another language. Keep names as written.", strings.TrimSpace(language))
+	}
+}
+
+// BuildSummaryPrompt returns a section summary or roll-up prompt with
+// the same language rule as BuildExtractionPrompt
+func BuildSummaryPrompt(prompt, language string) string {
+	switch strings.ToLower(strings.TrimSpace(language)) {
+	case "":
+		return prompt
+	case "document", "source", "original":
+		return strings.TrimSpace(prompt) + "\nWrite in the same language as the notes. Do not translate to English; keep names, terms and quotes as written."
+	default:
+		return strings.TrimSpace(prompt) + fmt.Sprintf("\nWrite in %s. Keep names as written.", strings.TrimSpace(language))
+	}
+}
+
 // BuildSkillPrompt wraps skill body for document processing
 func BuildSkillPrompt(skillBody string) string {
 	return fmt.Sprintf("Follow these instructions when processing the document:\n\n%s", skillBody)
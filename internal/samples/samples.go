@@ -0,0 +1,90 @@
+package samples
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Scheme prefixes bundled sample documents, e.g.
+// pulp://samples/quarterly-report.md
+const Scheme = "pulp://samples/"
+
+//go:embed files/*.md files/*.html
+var files embed.FS
+
+//go:embed skills
+var skills embed.FS
+
+// IsSample returns true if path refers to a bundled sample
+func IsSample(p string) bool {
+	return strings.HasPrefix(strings.ToLower(p), Scheme)
+}
+
+// List returns the URIs of all bundled samples
+func List() []string {
+	entries, _ := fs.ReadDir(files, "files")
+
+	var uris []string
+	for _, e := range entries {
+		uris = append(uris, Scheme+e.Name())
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// Read returns the contents of a bundled sample
+func Read(uri string) ([]byte, error) {
+	if !IsSample(uri) {
+		return nil, fmt.Errorf("not a sample: %s", uri)
+	}
+
+	name := uri[len(Scheme):]
+	// Reject anything that isn't a plain file name
+	if name == "" || name != path.Base(name) {
+		return nil, fmt.Errorf("sample not found: %s", uri)
+	}
+
+	data, err := files.ReadFile("files/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("sample not found: %s (available: %s)", uri, strings.Join(List(), ", "))
+	}
+	return data, nil
+}
+
+// InstallSkills copies the bundled demo skills into skillsDir.
+// Skills the user already has are left alone.
+func InstallSkills(skillsDir string) error {
+	entries, err := fs.ReadDir(skills, "skills")
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		dest := filepath.Join(skillsDir, e.Name(), "SKILL.md")
+		if _, err := os.Stat(dest); err == nil {
+			continue
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		data, err := skills.ReadFile(path.Join("skills", e.Name(), "SKILL.md"))
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
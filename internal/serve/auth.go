@@ -0,0 +1,201 @@
+package serve
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/llm"
+)
+
+const defaultRateLimit = 30 // Requests per minute
+
+// Usage is the per-token accounting exposed at /v1/usage
+type Usage struct {
+	Name             string    `json:"name"`
+	Requests         int       `json:"requests"`
+	Rejected         int       `json:"rejected"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	LastUsed         time.Time `json:"last_used"`
+}
+
+type client struct {
+	token     string
+	rateLimit int
+
+	// Fixed one-minute rate limit window
+	windowStart time.Time
+	windowCount int
+
+	usage Usage
+}
+
+// Auth checks API tokens, enforces rate limits and tracks usage
+type Auth struct {
+	mu      sync.Mutex
+	clients []*client
+	open    *client // Used when no tokens are configured
+	now     func() time.Time
+}
+
+// NewAuth creates token auth from the serve config.
+// With no tokens configured, every request is accepted as "local".
+func NewAuth(cfg *config.ServeConfig) *Auth {
+	a := &Auth{now: time.Now}
+
+	defaultLimit := defaultRateLimit
+	if cfg != nil && cfg.RateLimit > 0 {
+		defaultLimit = cfg.RateLimit
+	}
+
+	if cfg != nil {
+		for i, t := range cfg.Tokens {
+			if t.Token == "" {
+				continue
+			}
+			name := t.Name
+			if name == "" {
+				name = fmt.Sprintf("token-%d", i+1)
+			}
+			limit := t.RateLimit
+			if limit <= 0 {
+				limit = defaultLimit
+			}
+			a.clients = append(a.clients, &client{
+				token:     t.Token,
+				rateLimit: limit,
+				usage:     Usage{Name: name},
+			})
+		}
+	}
+
+	if len(a.clients) == 0 {
+		a.open = &client{
+			rateLimit: defaultLimit,
+			usage:     Usage{Name: "local"},
+		}
+	}
+
+	return a
+}
+
+// Enabled returns true if requests must carry a token
+func (a *Auth) Enabled() bool {
+	return a.open == nil
+}
+
+type callerKey struct{}
+
+// caller returns the client attached to an authenticated request, or
+// nil outside the middleware
+func caller(ctx context.Context) *client {
+	c, _ := ctx.Value(callerKey{}).(*client)
+	return c
+}
+
+// Caller returns the token name attached to an authenticated request
+func Caller(ctx context.Context) string {
+	if c := caller(ctx); c != nil {
+		return c.usage.Name
+	}
+	return ""
+}
+
+// Middleware rejects requests without a valid token (401) or over
+// their rate limit (429)
+func (a *Auth) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := a.lookup(requestToken(r))
+		if c == nil {
+			writeError(w, http.StatusUnauthorized, "invalid or missing API token")
+			return
+		}
+
+		if !a.allow(c) {
+			w.Header().Set("Retry-After", "60")
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+
+		// The client itself, not its name: names needn't be unique
+		ctx := context.WithValue(r.Context(), callerKey{}, c)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Record adds LLM token usage to the account of the request's caller
+func (a *Auth) Record(ctx context.Context, u llm.Usage) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if c := caller(ctx); c != nil {
+		c.usage.PromptTokens += u.PromptTokens
+		c.usage.CompletionTokens += u.CompletionTokens
+	}
+}
+
+// Usage returns a snapshot of the request caller's usage
+func (a *Auth) Usage(ctx context.Context) Usage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if c := caller(ctx); c != nil {
+		return c.usage
+	}
+	return Usage{}
+}
+
+func (a *Auth) lookup(token string) *client {
+	if a.open != nil {
+		return a.open
+	}
+	if token == "" {
+		return nil
+	}
+
+	// Compare against every token so timing doesn't leak which matched
+	var found *client
+	for _, c := range a.clients {
+		if subtle.ConstantTimeCompare([]byte(c.token), []byte(token)) == 1 {
+			found = c
+		}
+	}
+	return found
+}
+
+func (a *Auth) allow(c *client) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if now.Sub(c.windowStart) >= time.Minute {
+		c.windowStart = now
+		c.windowCount = 0
+	}
+
+	if c.windowCount >= c.rateLimit {
+		c.usage.Rejected++
+		return false
+	}
+
+	c.windowCount++
+	c.usage.Requests++
+	c.usage.LastUsed = now
+	return true
+}
+
+// requestToken reads "Authorization: Bearer <token>" or "X-API-Key"
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return strings.TrimSpace(r.Header.Get("X-API-Key"))
+}
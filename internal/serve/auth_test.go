@@ -0,0 +1,111 @@
+package serve
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/llm"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	auth := NewAuth(&config.ServeConfig{
+		Tokens: []config.ServeToken{
+			{Name: "alice", Token: "secret-a", RateLimit: 2},
+			{Name: "bob", Token: "secret-b"},
+		},
+	})
+
+	var caller string
+	var ctx context.Context
+	handler := auth.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller = Caller(r.Context())
+		ctx = r.Context()
+	}))
+
+	do := func(header, value string) int {
+		req := httptest.NewRequest("GET", "/v1/usage", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := do("", ""); code != http.StatusUnauthorized {
+		t.Errorf("missing token: status %d, want 401", code)
+	}
+	if code := do("Authorization", "Bearer wrong"); code != http.StatusUnauthorized {
+		t.Errorf("wrong token: status %d, want 401", code)
+	}
+
+	if code := do("X-API-Key", "secret-b"); code != http.StatusOK || caller != "bob" {
+		t.Errorf("X-API-Key: status %d caller %q, want 200 bob", code, caller)
+	}
+
+	// alice is limited to 2 requests per minute
+	for i := 0; i < 2; i++ {
+		if code := do("Authorization", "Bearer secret-a"); code != http.StatusOK {
+			t.Fatalf("request %d: status %d, want 200", i+1, code)
+		}
+	}
+	if code := do("Authorization", "Bearer secret-a"); code != http.StatusTooManyRequests {
+		t.Errorf("over limit: status %d, want 429", code)
+	}
+
+	// Window resets after a minute
+	auth.now = func() time.Time { return time.Now().Add(time.Minute) }
+	if code := do("Authorization", "Bearer secret-a"); code != http.StatusOK {
+		t.Errorf("after window: status %d, want 200", code)
+	}
+
+	auth.Record(ctx, llm.Usage{PromptTokens: 100, CompletionTokens: 20})
+	u := auth.Usage(ctx)
+	if u.Name != "alice" || u.Requests != 3 || u.Rejected != 1 || u.PromptTokens != 100 || u.CompletionTokens != 20 {
+		t.Errorf("usage = %+v", u)
+	}
+}
+
+func TestAuthKeepsDuplicateNamesApart(t *testing.T) {
+	auth := NewAuth(&config.ServeConfig{
+		Tokens: []config.ServeToken{
+			{Name: "team", Token: "secret-a"},
+			{Name: "team", Token: "secret-b"},
+		},
+	})
+
+	handler := auth.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth.Record(r.Context(), llm.Usage{PromptTokens: 10})
+	}))
+	for _, token := range []string{"secret-a", "secret-a", "secret-b"} {
+		req := httptest.NewRequest("POST", "/v1/process", nil)
+		req.Header.Set("X-API-Key", token)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if a, b := auth.clients[0].usage, auth.clients[1].usage; a.PromptTokens != 20 || b.PromptTokens != 10 {
+		t.Errorf("usage merged across tokens: %+v, %+v", a, b)
+	}
+}
+
+func TestAuthOpenWithoutTokens(t *testing.T) {
+	auth := NewAuth(nil)
+	if auth.Enabled() {
+		t.Fatal("auth enabled without tokens")
+	}
+
+	handler := auth.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/usage", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status %d, want 200", rec.Code)
+	}
+
+	if isLoopback("0.0.0.0:8080") || !isLoopback("127.0.0.1:8080") || !isLoopback("localhost:9000") {
+		t.Error("isLoopback misclassified addresses")
+	}
+}
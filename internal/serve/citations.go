@@ -0,0 +1,128 @@
+package serve
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sant0-9/pulp/internal/pipeline"
+	"github.com/sant0-9/pulp/internal/session"
+)
+
+// Footnote marker the writer puts on points that rely on a note: [^2]
+var noteMarkerPattern = regexp.MustCompile(`\[\^([^\]\s]+)\]`)
+
+// transcriptPage is a published session with what its result cites: the
+// document's footnotes and the sections the text refers to by number
+type transcriptPage struct {
+	*session.Session
+	Notes    []pipeline.Footnote
+	Sections []citedSection
+
+	outline *pipeline.Outline
+	notes   map[string]bool
+}
+
+// citedSection is a document section the result or conversation refers to
+type citedSection struct {
+	Label   string
+	Anchor  string
+	Excerpt string
+}
+
+func newTranscriptPage(sess *session.Session) *transcriptPage {
+	p := &transcriptPage{
+		Session: sess,
+		outline: pipeline.BuildOutline(sess.Markdown),
+		notes:   make(map[string]bool),
+	}
+	if sess.Aggregated != nil {
+		p.Notes = sess.Aggregated.Footnotes
+		for _, n := range p.Notes {
+			p.notes[n.Label] = true
+		}
+	}
+
+	// Sections in the order they are first cited
+	seen := make(map[*pipeline.Section]bool)
+	texts := []string{sess.Result}
+	for _, m := range sess.Conversation {
+		texts = append(texts, m.Content)
+	}
+	for _, text := range texts {
+		for _, ref := range p.outline.FindSectionRefs(text) {
+			if seen[ref.Section] {
+				continue
+			}
+			seen[ref.Section] = true
+			p.Sections = append(p.Sections, citedSection{
+				Label:   ref.Section.Label(),
+				Anchor:  p.anchor(ref.Section),
+				Excerpt: excerpt(ref.Section.Content, 300),
+			})
+		}
+	}
+	return p
+}
+
+// Cite escapes text for the page and links its citations: footnote
+// markers to the notes and section references to the cited sections
+func (p *transcriptPage) Cite(text string) template.HTML {
+	type link struct {
+		start, end int
+		html       string
+	}
+
+	var links []link
+	for _, m := range noteMarkerPattern.FindAllStringSubmatchIndex(text, -1) {
+		label := text[m[2]:m[3]]
+		if !p.notes[label] {
+			continue
+		}
+		links = append(links, link{m[0], m[1], fmt.Sprintf(`<sup><a href="#fn-%s">%s</a></sup>`,
+			template.HTMLEscapeString(label), template.HTMLEscapeString(label))})
+	}
+	for _, ref := range p.outline.FindSectionRefs(text) {
+		links = append(links, link{ref.Start, ref.End, fmt.Sprintf(`<a href="#%s">%s</a>`,
+			p.anchor(ref.Section), template.HTMLEscapeString(text[ref.Start:ref.End]))})
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].start < links[j].start })
+
+	var b strings.Builder
+	pos := 0
+	for _, l := range links {
+		if l.start < pos {
+			continue // Overlaps the previous link
+		}
+		b.WriteString(template.HTMLEscapeString(text[pos:l.start]))
+		b.WriteString(l.html)
+		pos = l.end
+	}
+	b.WriteString(template.HTMLEscapeString(text[pos:]))
+	return template.HTML(b.String())
+}
+
+// anchor returns the page anchor of a document section
+func (p *transcriptPage) anchor(s *pipeline.Section) string {
+	for i, o := range p.outline.Sections {
+		if o == s {
+			return fmt.Sprintf("sec-%d", i+1)
+		}
+	}
+	return ""
+}
+
+// excerpt shortens text to about n bytes, cutting at a word boundary
+func excerpt(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) <= n {
+		return text
+	}
+	cut := strings.LastIndex(text[:n], " ")
+	if cut <= 0 {
+		cut = n
+	}
+	return text[:cut] + "..."
+}
@@ -0,0 +1,257 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/intent"
+	"github.com/sant0-9/pulp/internal/llm"
+	"github.com/sant0-9/pulp/internal/pipeline"
+	"github.com/sant0-9/pulp/internal/session"
+	"github.com/sant0-9/pulp/internal/skill"
+	"github.com/sant0-9/pulp/internal/writer"
+)
+
+// DefaultAddr is used when neither the flag nor config set an address
+const DefaultAddr = "127.0.0.1:8080"
+
+// Server exposes the document pipeline over HTTP
+type Server struct {
+	config     *config.Config
+	provider   llm.Provider
+	skillIndex *skill.SkillIndex
+	store      *session.Store
+	auth       *Auth
+}
+
+// NewServer creates a server using the configured provider
+func NewServer(cfg *config.Config, provider llm.Provider) *Server {
+	skillIdx, _ := skill.NewSkillIndex()
+	// Published sessions are optional; without a store /s/ returns 404
+	store, _ := session.NewStore()
+	return &Server{
+		config:     cfg,
+		provider:   provider,
+		skillIndex: skillIdx,
+		store:      store,
+		auth:       NewAuth(cfg.Serve),
+	}
+}
+
+// Handler returns the HTTP routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /s/{id}", s.handleTranscript)
+	mux.Handle("POST /v1/process", s.auth.Middleware(http.HandlerFunc(s.handleProcess)))
+	mux.Handle("GET /v1/usage", s.auth.Middleware(http.HandlerFunc(s.handleUsage)))
+	return mux
+}
+
+// ListenAndServe starts the server on addr. Without configured
+// tokens it refuses to listen on anything but loopback.
+func (s *Server) ListenAndServe(addr string) error {
+	if !s.auth.Enabled() && !isLoopback(addr) {
+		return fmt.Errorf("refusing to serve on %s without API tokens (add serve.tokens to config)", addr)
+	}
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
+
+type processRequest struct {
+	Title       string `json:"title"`
+	Content     string `json:"content"` // Markdown or plain text
+	Instruction string `json:"instruction"`
+}
+
+type processResponse struct {
+	Result string `json:"result"`
+	Usage  Usage  `json:"usage"`
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.auth.Usage(r.Context()))
+}
+
+func (s *Server) handleProcess(w http.ResponseWriter, r *http.Request) {
+	var req processRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 10<<20)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		writeError(w, http.StatusBadRequest, "content is required")
+		return
+	}
+	if req.Title == "" {
+		req.Title = "Untitled"
+	}
+	// The TUI asks before processing huge documents; here nobody can
+	if words, limit := len(strings.Fields(req.Content)), s.config.DocLimits().MaxWords; words > limit {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("content has %d words, over the limit of %d (limits.max_words)", words, limit))
+		return
+	}
+
+	metered := &meteredProvider{Provider: s.provider}
+
+	// The pipeline and writer stop when the client leaves or time runs out
+	timeout := s.requestTimeout()
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	result, err := s.process(ctx, metered, &req)
+	// Failed requests still consumed tokens
+	s.auth.Record(r.Context(), metered.Usage())
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, fmt.Sprintf("request timed out after %s", timeout))
+		return
+	case errors.Is(err, context.Canceled):
+		return // Client disconnected
+	case err != nil:
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, processResponse{Result: result, Usage: s.auth.Usage(r.Context())})
+}
+
+func (s *Server) requestTimeout() time.Duration {
+	if s.config.Serve != nil && s.config.Serve.RequestTimeout > 0 {
+		return time.Duration(s.config.Serve.RequestTimeout) * time.Second
+	}
+	return config.DefaultRequestTimeout * time.Second
+}
+
+func (s *Server) process(ctx context.Context, provider llm.Provider, req *processRequest) (string, error) {
+	doc := &converter.Document{
+		Content: req.Content,
+		Metadata: converter.Metadata{
+			Title:        req.Title,
+			SourceFormat: "md",
+			WordCount:    len(strings.Fields(req.Content)),
+		},
+	}
+
+	parser := intent.NewParser(provider, s.config.Model, s.skillIndex)
+	parsed, err := parser.Parse(ctx, req.Instruction)
+	if err != nil {
+		parsed = intent.New(req.Instruction)
+	}
+
+	pipe := pipeline.NewPipeline(provider, s.config.Model)
+	pipe.SetLanguage(s.config.ExtractionLanguage)
+	result, err := pipe.Process(ctx, doc, parsed)
+	if err != nil {
+		return "", err
+	}
+
+	w := writer.NewWriter(provider, s.config.Model)
+	gen := s.config.GenerationSettings()
+	// No preamble: it describes the host's owner, not whoever is
+	// calling a shared server
+	w.SetParams(gen.Temperature, gen.MaxTokens)
+	return w.Write(ctx, &writer.WriteRequest{
+		Aggregated: result.Aggregated,
+		Intent:     parsed,
+		DocTitle:   req.Title,
+	})
+}
+
+// meteredProvider counts token usage for one request. Providers that
+// don't report usage are counted with the pipeline's estimate.
+type meteredProvider struct {
+	llm.Provider
+
+	mu    sync.Mutex
+	usage llm.Usage
+}
+
+func (m *meteredProvider) Complete(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	resp, err := m.Provider.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	u := resp.Usage
+	if u.TotalTokens == 0 {
+		for _, msg := range req.Messages {
+			u.PromptTokens += pipeline.EstimateTokens(msg.Content)
+		}
+		u.CompletionTokens = pipeline.EstimateTokens(resp.Content)
+	}
+
+	m.mu.Lock()
+	m.usage.PromptTokens += u.PromptTokens
+	m.usage.CompletionTokens += u.CompletionTokens
+	m.usage.TotalTokens += u.PromptTokens + u.CompletionTokens
+	m.mu.Unlock()
+
+	return resp, nil
+}
+
+// MaxInFlight passes the wrapped provider's limit to the pipeline
+func (m *meteredProvider) MaxInFlight() int {
+	return llm.MaxInFlight(m.Provider)
+}
+
+// Capabilities passes the wrapped provider's capabilities to the
+// pipeline and writer
+func (m *meteredProvider) Capabilities() llm.Capabilities {
+	return llm.CapabilitiesOf(m.Provider)
+}
+
+// Usage returns the tokens used so far
+func (m *meteredProvider) Usage() llm.Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usage
+}
+
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// Banner describes how the server is exposed, for startup output
+func (s *Server) Banner(addr string) string {
+	if !s.auth.Enabled() {
+		return fmt.Sprintf("pulp serve listening on %s (no tokens configured, auth disabled)", addr)
+	}
+	return fmt.Sprintf("pulp serve listening on %s (%d API tokens)", addr, len(s.auth.clients))
+}
@@ -0,0 +1,32 @@
+package serve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/llm"
+)
+
+func TestMeteredProviderKeepsCapabilities(t *testing.T) {
+	p := llm.NewOpenAIProvider("k", "gpt-4o-mini")
+	want := llm.CapabilitiesOf(p)
+	if got := llm.CapabilitiesOf(&meteredProvider{Provider: p}); got != want {
+		t.Errorf("CapabilitiesOf(metered) = %+v, want %+v", got, want)
+	}
+}
+
+func TestProcessRejectsHugeDocuments(t *testing.T) {
+	cfg := &config.Config{Limits: &config.LimitsConfig{MaxWords: 5}}
+	s := &Server{config: cfg, auth: NewAuth(nil)}
+
+	body := `{"content": "one two three four five six", "instruction": "summarize"}`
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/process", strings.NewReader(body)))
+
+	if rec.Code != http.StatusRequestEntityTooLarge || !strings.Contains(rec.Body.String(), "6 words") {
+		t.Errorf("POST /v1/process = %d %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,95 @@
+package serve
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"github.com/sant0-9/pulp/internal/session"
+)
+
+var transcriptTemplate = template.Must(template.New("transcript").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Document.Title}} - Pulp</title>
+<style>
+body { font-family: -apple-system, system-ui, sans-serif; max-width: 760px; margin: 2rem auto; padding: 0 1rem; color: #1F2937; line-height: 1.5; }
+h1 { color: #7C3AED; margin-bottom: 0; }
+.meta { color: #6B7280; margin-top: .25rem; }
+.turn { margin: 1rem 0; padding: .75rem 1rem; border-radius: 8px; white-space: pre-wrap; }
+.user { background: #ECFEFF; border-left: 3px solid #06B6D4; }
+.assistant { background: #F9FAFB; border-left: 3px solid #7C3AED; }
+.result { border: 1px solid #7C3AED; border-radius: 8px; padding: 1rem; white-space: pre-wrap; }
+.extracted li, .notes li { margin-bottom: .25rem; }
+.cited { margin: .75rem 0; padding-left: .75rem; border-left: 3px solid #E5E7EB; }
+.cited p { margin: .25rem 0 0; color: #4B5563; }
+footer { color: #6B7280; font-size: .85rem; margin-top: 3rem; }
+</style>
+</head>
+<body>
+<h1>{{.Document.Title}}</h1>
+<p class="meta">{{.Document.SourceFormat}} &middot; ~{{.Document.WordCount}} words &middot; shared {{.ExportedAt.Format "Jan 2, 2006"}}</p>
+
+<h2>Result</h2>
+<div class="result">{{.Cite .Result}}</div>
+
+{{if .Sections}}
+<h2>Cited sections</h2>
+{{range .Sections}}<div class="cited" id="{{.Anchor}}"><strong>{{.Label}}</strong><p>{{.Excerpt}}</p></div>
+{{end}}{{end}}
+
+{{if .Notes}}
+<h2>Notes</h2>
+<ul class="notes">
+{{range .Notes}}<li id="fn-{{.Label}}"><sup>{{.Label}}</sup> {{.Text}}</li>
+{{end}}</ul>
+{{end}}
+
+{{with .Aggregated}}{{if or .Facts .KeyPoints}}
+<h2>Extracted notes</h2>
+<p class="meta">Facts and key points taken from the document to write the result.</p>
+<ul class="extracted">
+{{range .Facts}}<li>{{.}}</li>
+{{end}}{{range .KeyPoints}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}{{end}}
+
+{{if .Conversation}}
+<h2>Transcript</h2>
+{{range .Conversation}}<div class="turn {{.Role}}">{{$.Cite .Content}}</div>
+{{end}}{{end}}
+
+<footer>Read-only transcript shared from Pulp</footer>
+</body>
+</html>
+`))
+
+// handleTranscript renders a published session as a read-only page.
+// Session IDs are unguessable, so the link itself grants access. Chat
+// summaries share the store but are private and never served.
+func (s *Server) handleTranscript(w http.ResponseWriter, r *http.Request) {
+	if s.store == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	sess, err := s.store.Load(r.PathValue("id"))
+	if err != nil || sess.Kind == session.KindChat {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Render first so a template error isn't sent as half a page
+	var page bytes.Buffer
+	if err := transcriptTemplate.Execute(&page, newTranscriptPage(sess)); err != nil {
+		http.Error(w, "could not render transcript", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-store")
+	page.WriteTo(w)
+}
@@ -0,0 +1,68 @@
+package serve
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/pipeline"
+	"github.com/sant0-9/pulp/internal/session"
+)
+
+func TestTranscriptHidesChatSummaries(t *testing.T) {
+	st, err := session.NewStoreAt(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	published, err := st.Save(&session.Session{Document: converter.Metadata{Title: "Q3 Report"}, Result: "Revenue grew."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chat, err := st.Save(&session.Session{Kind: session.KindChat, Document: converter.Metadata{Title: "pricing ideas"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{store: st, auth: NewAuth(nil)}
+	get := func(id string) int {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/s/"+id, nil))
+		return rec.Code
+	}
+	if code := get(published); code != http.StatusOK {
+		t.Errorf("published session: status %d, want 200", code)
+	}
+	if code := get(chat); code != http.StatusNotFound {
+		t.Errorf("chat summary: status %d, want 404", code)
+	}
+}
+
+func TestTranscriptCitations(t *testing.T) {
+	sess := &session.Session{
+		Document: converter.Metadata{Title: "Q3 Report"},
+		Markdown: "# Q3 Report\n\n## Revenue\n\nRevenue grew 12%.\n\n## Churn\n\nChurn fell to 4%.",
+		Aggregated: &pipeline.AggregatedContent{
+			Footnotes: []pipeline.Footnote{{Label: "1", Text: "Excludes one-off deals."}},
+		},
+		Result: "Revenue grew <fast> [^1], see section 2.",
+	}
+
+	var page bytes.Buffer
+	if err := transcriptTemplate.Execute(&page, newTranscriptPage(sess)); err != nil {
+		t.Fatal(err)
+	}
+	html := page.String()
+	for _, want := range []string{
+		`Revenue grew &lt;fast&gt; <sup><a href="#fn-1">1</a></sup>`,
+		`<a href="#sec-3">section 2</a>`,
+		`<div class="cited" id="sec-3"><strong>2. Churn</strong><p>Churn fell to 4%.</p></div>`,
+		`<li id="fn-1"><sup>1</sup> Excludes one-off deals.</li>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("page is missing %s", want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package session
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BundleExt is the file extension for exported sessions
+const BundleExt = ".pulp"
+
+// Bundle layout (a zip archive):
+//
+//	session.json  metadata, extraction and conversation
+//	document.md   converted document markdown
+//	result.md     the latest result
+const (
+	sessionFile  = "session.json"
+	documentFile = "document.md"
+	resultFile   = "result.md"
+)
+
+// maxBundleFile caps each file read from a bundle, so a crafted archive
+// can't inflate into gigabytes of memory
+var maxBundleFile int64 = 100 << 20
+
+// Export writes the session as a bundle
+func (s *Session) Export(w io.Writer) error {
+	s.Version = FormatVersion
+	s.ExportedAt = time.Now()
+
+	zw := zip.NewWriter(w)
+
+	meta, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{sessionFile, meta},
+		{documentFile, []byte(s.Markdown)},
+		{resultFile, []byte(s.Result)},
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// ExportFile writes the bundle to path, creating parent directories
+func (s *Session) ExportFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Export(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Import reads a bundle written by Export
+func Import(r io.ReaderAt, size int64) (*Session, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("not a pulp session bundle: %w", err)
+	}
+
+	contents := make(map[string][]byte)
+	for _, f := range zr.File {
+		switch f.Name {
+		case sessionFile, documentFile, resultFile:
+		default:
+			continue
+		}
+		data, err := readBundleFile(f)
+		if err != nil {
+			return nil, err
+		}
+		contents[f.Name] = data
+	}
+
+	meta, ok := contents[sessionFile]
+	if !ok {
+		return nil, fmt.Errorf("not a pulp session bundle: missing %s", sessionFile)
+	}
+
+	var s Session
+	if err := json.Unmarshal(meta, &s); err != nil {
+		return nil, fmt.Errorf("invalid session metadata: %w", err)
+	}
+	if s.Version > FormatVersion {
+		return nil, fmt.Errorf("session bundle version %d is newer than this pulp supports (%d)", s.Version, FormatVersion)
+	}
+
+	s.Markdown = string(contents[documentFile])
+	s.Result = string(contents[resultFile])
+
+	return &s, nil
+}
+
+// readBundleFile reads one file from a bundle, up to maxBundleFile
+func readBundleFile(f *zip.File) ([]byte, error) {
+	if f.UncompressedSize64 > uint64(maxBundleFile) {
+		return nil, fmt.Errorf("%s in bundle is too large (%d bytes)", f.Name, f.UncompressedSize64)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	// The header's size can lie, so the read is capped as well
+	data, err := io.ReadAll(io.LimitReader(rc, maxBundleFile+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBundleFile {
+		return nil, fmt.Errorf("%s in bundle is too large", f.Name)
+	}
+	return data, nil
+}
+
+// bundleHeader is the part of session.json a listing needs
+type bundleHeader struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Kind       string    `json:"kind,omitempty"`
+	Document   struct {
+		Title string `json:"title"`
+	} `json:"document"`
+}
+
+// readHeader reads a bundle's listing fields from session.json alone,
+// leaving the document and result unread
+func readHeader(path string) (*bundleHeader, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("not a pulp session bundle: %w", err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != sessionFile {
+			continue
+		}
+		data, err := readBundleFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var h bundleHeader
+		if err := json.Unmarshal(data, &h); err != nil {
+			return nil, fmt.Errorf("invalid session metadata: %w", err)
+		}
+		return &h, nil
+	}
+	return nil, fmt.Errorf("not a pulp session bundle: missing %s", sessionFile)
+}
+
+// ImportFile reads a bundle from disk
+func ImportFile(path string) (*Session, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return Import(f, info.Size())
+}
+
+// IsBundle returns true if path has the bundle extension
+func IsBundle(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), BundleExt)
+}
+
+// BundleName returns a filename for exporting a document's session
+func BundleName(title string) string {
+	name := strings.NewReplacer(" ", "_", "/", "_", "\\", "_").Replace(strings.TrimSpace(title))
+	if name == "" {
+		name = "session"
+	}
+	return name + BundleExt
+}
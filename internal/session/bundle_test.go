@@ -0,0 +1,68 @@
+package session
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/pipeline"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	s := &Session{
+		Document: converter.Metadata{Title: "Q3 Report", SourceFormat: "pdf", WordCount: 3},
+		Markdown: "# Q3\n\nRevenue grew.",
+		Aggregated: &pipeline.AggregatedContent{
+			KeyPoints: []string{"Revenue grew"},
+		},
+		Conversation: []Message{
+			{Role: "user", Content: "summarize"},
+			{Role: "assistant", Content: "Revenue grew."},
+			{Role: "user", Content: "shorter"},
+		},
+		Result: "Revenue up.",
+	}
+
+	var buf bytes.Buffer
+	if err := s.Export(&buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	got, err := Import(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if got.Document.Title != "Q3 Report" || got.Markdown != s.Markdown || got.Result != s.Result {
+		t.Errorf("Import() = %+v, want document and result preserved", got)
+	}
+	if len(got.Conversation) != 3 || got.LastInstruction() != "shorter" {
+		t.Errorf("conversation = %+v", got.Conversation)
+	}
+	if got.Aggregated == nil || got.Aggregated.KeyPoints[0] != "Revenue grew" {
+		t.Errorf("aggregated = %+v", got.Aggregated)
+	}
+}
+
+func TestImportRejectsNonBundle(t *testing.T) {
+	data := []byte("not a zip")
+	if _, err := Import(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Error("Import() accepted a non-bundle")
+	}
+}
+
+func TestImportRejectsOversizedFiles(t *testing.T) {
+	defer func(n int64) { maxBundleFile = n }(maxBundleFile)
+	maxBundleFile = 1000
+
+	s := &Session{Document: converter.Metadata{Title: "Big"}, Markdown: strings.Repeat("a", 5000)}
+	var buf bytes.Buffer
+	if err := s.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Import(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("Import() = %v, want too large", err)
+	}
+}
@@ -0,0 +1,62 @@
+package session
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/pipeline"
+)
+
+// FormatVersion is bumped when the bundle layout changes
+const FormatVersion = 1
+
+// KindChat marks a saved chat summary; document sessions leave Kind empty
+const KindChat = "chat"
+
+// Session is a document analysis that can be handed off to another user
+type Session struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Kind       string    `json:"kind,omitempty"`
+
+	Document converter.Metadata `json:"document"`
+	Markdown string             `json:"-"` // Stored as document.md in the bundle
+
+	// Extraction results, so the importer can continue with follow-ups
+	// without re-running the pipeline
+	Aggregated *pipeline.AggregatedContent `json:"aggregated,omitempty"`
+
+	Conversation []Message `json:"conversation"`
+	Result       string    `json:"-"` // Stored as result.md in the bundle
+}
+
+// Message is one turn of the document conversation
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// LastInstruction returns the most recent user message
+func (s *Session) LastInstruction() string {
+	for i := len(s.Conversation) - 1; i >= 0; i-- {
+		if s.Conversation[i].Role == "user" {
+			return s.Conversation[i].Content
+		}
+	}
+	return ""
+}
+
+// ToDocument rebuilds the converted document from the session
+func (s *Session) ToDocument() *converter.Document {
+	// Match the bridge's preview: first 500 characters
+	preview := []rune(strings.TrimSpace(s.Markdown))
+	if len(preview) > 500 {
+		preview = append([]rune(strings.TrimSpace(string(preview[:500]))), []rune("...")...)
+	}
+	return &converter.Document{
+		Content:  s.Markdown,
+		Preview:  string(preview),
+		Metadata: s.Document,
+	}
+}
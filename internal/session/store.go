@@ -0,0 +1,107 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sant0-9/pulp/internal/config"
+)
+
+// Store keeps published sessions and chat summaries as bundles in
+// ~/.config/pulp/sessions/, and result versions under versions/.
+// IDs are random so a session URL can be shared without auth.
+type Store struct {
+	dir string
+}
+
+var idPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// NewStore opens the session store, creating its directory
+func NewStore() (*Store, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreAt(filepath.Join(dir, "sessions"))
+}
+
+// NewStoreAt opens a session store in dir
+func NewStoreAt(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Save publishes a session and returns its ID
+func (st *Store) Save(s *Session) (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(buf)
+
+	if err := s.ExportFile(st.path(id)); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Load reads a published session
+func (st *Store) Load(id string) (*Session, error) {
+	if !idPattern.MatchString(id) {
+		return nil, fmt.Errorf("invalid session id: %q", id)
+	}
+	return ImportFile(st.path(id))
+}
+
+// Entry describes a stored session for listings
+type Entry struct {
+	ID      string
+	Title   string
+	Kind    string
+	SavedAt time.Time
+}
+
+// List returns stored sessions of the given kind, newest first. Only
+// each bundle's metadata is read.
+func (st *Store) List(kind string) ([]Entry, error) {
+	matches, err := filepath.Glob(filepath.Join(st.dir, "*"+BundleExt))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, m := range matches {
+		id := strings.TrimSuffix(filepath.Base(m), BundleExt)
+		if !idPattern.MatchString(id) {
+			continue
+		}
+		h, err := readHeader(m)
+		if err != nil || h.Version > FormatVersion || h.Kind != kind {
+			continue // Skip unreadable bundles
+		}
+		entries = append(entries, Entry{
+			ID:      id,
+			Title:   h.Document.Title,
+			Kind:    h.Kind,
+			SavedAt: h.ExportedAt,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].SavedAt.After(entries[j].SavedAt)
+	})
+	return entries, nil
+}
+
+func (st *Store) path(id string) string {
+	return filepath.Join(st.dir, id+BundleExt)
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sant0-9/pulp/internal/converter"
+)
+
+func TestStoreListFiltersByKind(t *testing.T) {
+	st, err := NewStoreAt(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := st.Save(&Session{Document: converter.Metadata{Title: "Q3 Report"}}); err != nil {
+		t.Fatal(err)
+	}
+	id, err := st.Save(&Session{
+		Kind:     KindChat,
+		Document: converter.Metadata{Title: "pricing ideas"},
+		Result:   "Topic: pricing",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chats, err := st.List(KindChat)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(chats) != 1 || chats[0].ID != id || chats[0].Title != "pricing ideas" {
+		t.Errorf("List(KindChat) = %+v, want only the chat summary", chats)
+	}
+}
+
+func TestStoreListReadsOnlyMetadata(t *testing.T) {
+	defer func(n int64) { maxBundleFile = n }(maxBundleFile)
+
+	st, err := NewStoreAt(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.Save(&Session{Document: converter.Metadata{Title: "Long"}, Markdown: strings.Repeat("a", 5000)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The document is over the cap, which Import would reject
+	maxBundleFile = 1000
+	entries, err := st.List("")
+	if err != nil || len(entries) != 1 || entries[0].Title != "Long" {
+		t.Errorf("List() = %+v, %v", entries, err)
+	}
+}
+
+func TestStoreVersions(t *testing.T) {
+	st, err := NewStoreAt(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := DocumentKey("# Q3\n\nRevenue grew.")
+
+	if got, err := st.Versions(key); err != nil || len(got) != 0 {
+		t.Fatalf("Versions() = %v, %v, want empty", got, err)
+	}
+
+	st.SaveVersion(key, Version{Instruction: "summarize", Result: "Revenue grew."})
+	v, err := st.SaveVersion(key, Version{Instruction: "shorter", FollowUp: true, Result: "Up."})
+	if err != nil || v.Number != 2 {
+		t.Fatalf("SaveVersion() = %+v, %v", v, err)
+	}
+
+	got, err := st.Versions(key)
+	if err != nil || len(got) != 2 || got[0].Result != "Revenue grew." || got[1].Instruction != "shorter" {
+		t.Errorf("Versions() = %+v, %v", got, err)
+	}
+	if _, err := st.Versions("../config"); err == nil {
+		t.Error("Versions() accepted an invalid key")
+	}
+}
@@ -0,0 +1,81 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// Version is one generated result for a document, with the instruction
+// that produced it
+type Version struct {
+	Number      int       `json:"number"`
+	Instruction string    `json:"instruction"`
+	Skill       string    `json:"skill,omitempty"`
+	FollowUp    bool      `json:"follow_up,omitempty"`
+	Result      string    `json:"result"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+var keyPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// DocumentKey identifies a document by its content, so versions survive
+// renames and reloads of the same file
+func DocumentKey(markdown string) string {
+	sum := sha256.Sum256([]byte(markdown))
+	return hex.EncodeToString(sum[:16])
+}
+
+// SaveVersion appends a result to the document's history and returns it
+// with its number assigned
+func (st *Store) SaveVersion(key string, v Version) (Version, error) {
+	versions, err := st.Versions(key)
+	if err != nil {
+		return v, err
+	}
+
+	v.Number = len(versions) + 1
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = time.Now()
+	}
+	versions = append(versions, v)
+
+	data, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return v, err
+	}
+	if err := os.MkdirAll(filepath.Dir(st.versionsPath(key)), 0755); err != nil {
+		return v, err
+	}
+	return v, os.WriteFile(st.versionsPath(key), data, 0644)
+}
+
+// Versions returns the document's results, oldest first
+func (st *Store) Versions(key string) ([]Version, error) {
+	if !keyPattern.MatchString(key) {
+		return nil, errors.New("invalid document key")
+	}
+
+	data, err := os.ReadFile(st.versionsPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (st *Store) versionsPath(key string) string {
+	return filepath.Join(st.dir, "versions", key+".json")
+}
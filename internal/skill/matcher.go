@@ -43,24 +43,27 @@ func (m *Matcher) Match(ctx context.Context, instruction string) (*MatchResult,
 		return nil, nil
 	}
 
-	prompt := m.buildMatchingPrompt(instruction, allSkills)
-
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	resp, err := m.provider.Complete(ctx, &llm.CompletionRequest{
+	resp, err := m.provider.Complete(ctx, m.Request(instruction))
+	if err != nil {
+		return nil, err
+	}
+
+	return m.parseResponse(resp.Content, allSkills)
+}
+
+// Request builds the matching request for an instruction
+func (m *Matcher) Request(instruction string) *llm.CompletionRequest {
+	return &llm.CompletionRequest{
 		Model: m.model,
 		Messages: []llm.Message{
-			{Role: "user", Content: prompt},
+			{Role: "user", Content: m.buildMatchingPrompt(instruction, m.index.GetAll())},
 		},
 		MaxTokens:   100,
 		Temperature: 0.1,
-	})
-	if err != nil {
-		return nil, err
 	}
-
-	return m.parseResponse(resp.Content, allSkills)
 }
 
 func (m *Matcher) buildMatchingPrompt(instruction string, skills []*SkillMetadata) string {
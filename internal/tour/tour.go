@@ -0,0 +1,9 @@
+package tour
+
+// SampleURI is the bundled document the tour loads
+const SampleURI = "pulp://samples/launch-memo.md"
+
+// Steps walks through the main features in the order the tour uses
+// them. Each ID has "tour.<id>.title" and "tour.<id>.hint" messages in
+// the UI catalog.
+var Steps = []string{"load", "summarize", "skill", "chat"}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sant0-9/pulp/internal/anonymize"
+	"github.com/sant0-9/pulp/internal/llm"
+)
+
+// anonymizeDocument makes a redacted copy of the document. Names are
+// found with a model on this machine when there is one; the document
+// never goes to a cloud provider for this.
+func (a *App) anonymizeDocument() tea.Cmd {
+	doc := a.state.document
+	provider, model := a.localModel()
+	a.state.anonymizing = true
+	a.state.input.Reset()
+
+	return func() tea.Msg {
+		copied, n, err := anonymize.Document(context.Background(), doc, provider, model)
+		patternsOnly := provider == nil
+		if err != nil {
+			// Local model unreachable: patterns still catch most of it
+			copied, n, err = anonymize.Document(context.Background(), doc, nil, "")
+			patternsOnly = true
+		}
+		return anonymizedMsg{doc: copied, count: n, patternsOnly: patternsOnly, err: err}
+	}
+}
+
+// localModel returns the local extraction model, or the main provider
+// if it runs locally, or nil when only cloud models are configured
+func (a *App) localModel() (llm.Provider, string) {
+	cfg := a.state.config
+	if p, err := llm.NewLocalProvider(cfg); err == nil && p != nil {
+		return p, cfg.Local.Model
+	}
+	if cfg.Provider == "ollama" && a.state.provider != nil {
+		return a.state.provider, cfg.Model
+	}
+	return nil, ""
+}
+
+// useAnonymized makes the redacted copy the current document, so
+// instructions run on it instead of the original
+func (a *App) useAnonymized() tea.Cmd {
+	doc := a.state.anonymized
+	a.state.anonymized = nil
+	a.state.documentPath = ""
+	a.state.currentIntent = nil
+	a.state.pipelineResult = nil
+	a.state.result = ""
+	a.state.notice = ""
+	a.state.history = nil
+	a.state.isFollowUp = false
+	return func() tea.Msg { return documentLoadedMsg{doc} }
+}
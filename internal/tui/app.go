@@ -2,7 +2,9 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -12,13 +14,20 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/sant0-9/pulp/internal/config"
 	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/dryrun"
+	"github.com/sant0-9/pulp/internal/i18n"
 	"github.com/sant0-9/pulp/internal/intent"
 	"github.com/sant0-9/pulp/internal/llm"
+	"github.com/sant0-9/pulp/internal/output"
 	"github.com/sant0-9/pulp/internal/pipeline"
 	"github.com/sant0-9/pulp/internal/prompts"
+	"github.com/sant0-9/pulp/internal/samples"
+	"github.com/sant0-9/pulp/internal/session"
 	"github.com/sant0-9/pulp/internal/skill"
+	"github.com/sant0-9/pulp/internal/tour"
 	"github.com/sant0-9/pulp/internal/writer"
 )
 
@@ -44,6 +53,8 @@ type App struct {
 	state    *state
 	quitting bool
 	program  *tea.Program
+
+	httpClient *http.Client // For provider API calls; nil uses the default
 }
 
 // SetProgram sets the tea.Program reference for async messaging
@@ -51,11 +62,24 @@ func (a *App) SetProgram(p *tea.Program) {
 	a.program = p
 }
 
+// newProvider creates the configured provider
+func (a *App) newProvider() (llm.Provider, error) {
+	return llm.NewProviderWithClient(a.state.config, a.httpClient)
+}
+
 func NewApp() *App {
+	cfg, _ := config.Load()
+
+	// UI language: config, then LANG; needed before building inputs
+	var lang string
+	if cfg != nil {
+		lang = cfg.Language
+	}
+	i18n.SetLanguage(i18n.Detect(lang))
+
 	s := newState()
 
 	// Check if setup needed
-	cfg, _ := config.Load()
 	if cfg == nil {
 		s.needsSetup = true
 		s.config = config.DefaultConfig()
@@ -80,12 +104,13 @@ func (a *App) Init() tea.Cmd {
 		tea.WindowSize(),
 		textinput.Blink,
 		a.testProvider(),
+		loadChatSummaries(),
 	)
 }
 
 func (a *App) testProvider() tea.Cmd {
 	return func() tea.Msg {
-		provider, err := llm.NewProvider(a.state.config)
+		provider, err := a.newProvider()
 		if err != nil {
 			return providerErrorMsg{err}
 		}
@@ -118,6 +143,8 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case setupCompleteMsg:
 		a.state.needsSetup = false
 		a.view = viewWelcome
+		a.state.skillIndex, _ = skill.NewSkillIndex()
+		a.startTour()
 		return a, a.testProvider()
 
 	case setupErrorMsg:
@@ -126,7 +153,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case providerReadyMsg:
 		a.state.providerReady = true
-		provider, _ := llm.NewProvider(a.state.config)
+		provider, _ := a.newProvider()
 		a.state.provider = provider
 		a.state.input.Focus()
 		return a, textinput.Blink
@@ -139,17 +166,52 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.state.loadingDoc = false
 		a.state.document = msg.doc
 		a.state.docError = nil
+		a.state.docType = intent.Classify(msg.doc.Metadata.Title, msg.doc.Content)
+		a.state.suggestions = intent.Suggestions(a.state.docType, intent.LoadRecent(), 4)
+		a.state.hierarchical = false
+		a.state.versions = nil
+		a.state.versionIndex = -1
+		a.advanceTour(tourStepLoad)
 		a.view = viewDocument
 		a.state.input.Reset()
-		a.state.input.Placeholder = "What do you want to do with this document?"
+		a.state.input.Placeholder = i18n.T("document.prompt")
+
+		// Huge documents need explicit confirmation before processing
+		a.state.largeDoc = a.checkDocumentSize(msg.doc)
+		if a.state.largeDoc != nil {
+			a.state.input.Blur()
+			return a, a.loadVersions()
+		}
 		a.state.input.Focus()
-		return a, textinput.Blink
+		if cmd := a.runQueued(); cmd != nil {
+			return a, tea.Batch(cmd, a.loadVersions())
+		}
+		return a, tea.Batch(textinput.Blink, a.loadVersions())
 
 	case documentErrorMsg:
 		a.state.loadingDoc = false
+		a.state.queued = ""
 		a.state.docError = msg.error
 		return a, nil
 
+	case anonymizedMsg:
+		a.state.anonymizing = false
+		if msg.err != nil {
+			a.state.docError = msg.err
+			return a, nil
+		}
+		a.state.anonymized = msg.doc
+		a.state.result = msg.doc.Content
+		a.state.resultIsPlan = false
+		a.state.versionIndex = -1
+		a.state.notice = i18n.T("notice.anonymized", msg.count)
+		if msg.patternsOnly {
+			a.state.notice = i18n.T("notice.anonymized_patterns", msg.count)
+		}
+		a.state.input.Blur()
+		a.view = viewResult
+		return a, nil
+
 	case intentParsedMsg:
 		a.state.parsingIntent = false
 		a.state.currentIntent = msg.intent
@@ -158,6 +220,8 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Skip pipeline, go straight to writer (reuse cached extraction)
 			a.state.streaming = true
 			a.state.result = ""
+			a.state.versionIndex = -1
+			a.state.notice = ""
 			a.view = viewResult
 			return a, a.startWriter()
 		}
@@ -171,9 +235,12 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case pipelineDoneMsg:
+		a.state.activePipeline = nil
 		a.state.pipelineResult = msg.result
 		a.state.streaming = true
 		a.state.result = ""
+		a.state.versionIndex = -1
+		a.state.notice = ""
 		a.view = viewResult
 		return a, a.startWriter()
 
@@ -183,29 +250,78 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case streamDoneMsg:
 		a.state.streaming = false
+		a.state.activeWriter = nil
 		a.state.history = append(a.state.history, message{
 			role:    "assistant",
 			content: a.state.result,
 		})
+		a.advanceTour(tourStepSummarize)
 		a.state.input.Focus() // Focus input for follow-up
-		return a, textinput.Blink
+		return a, tea.Batch(textinput.Blink, a.saveVersion())
+
+	case versionsMsg:
+		if a.state.document == nil || msg.key != session.DocumentKey(a.state.document.Content) {
+			return a, nil // Document changed meanwhile
+		}
+		a.state.versions = msg.versions
+		a.state.versionIndex = -1
+		if n := len(msg.versions); n > 0 && (msg.latest || msg.versions[n-1].Result == a.state.result) {
+			a.state.versionIndex = n - 1
+		}
+		return a, nil
 
 	case streamErrorMsg:
 		a.state.streaming = false
+		a.state.activeWriter = nil
+		if errors.Is(msg.error, context.Canceled) {
+			// Keep the partial result so a follow-up can pick it up
+			a.state.history = append(a.state.history, message{
+				role:    "assistant",
+				content: a.state.result,
+			})
+			a.state.notice = i18n.T("notice.cancelled")
+			a.state.input.Focus()
+			return a, textinput.Blink
+		}
 		a.state.processingError = msg.error
 		return a, nil
 
 	case clipboardMsg:
-		// Could show notification
+		if msg.err != nil {
+			a.state.notice = i18n.T("notice.copy_failed", msg.err.Error())
+		} else {
+			a.state.notice = i18n.T("notice.copied")
+		}
 		return a, nil
 
 	case saveMsg:
-		// Could show notification with path
+		if msg.err != nil {
+			a.state.notice = i18n.T("notice.save_failed", msg.err.Error())
+		} else {
+			a.state.notice = i18n.T("notice.saved", msg.path)
+		}
+		return a, nil
+
+	case publishedMsg:
+		if msg.err != nil {
+			a.state.notice = i18n.T("notice.publish_failed", msg.err.Error())
+		} else {
+			a.state.notice = i18n.T("notice.published", msg.id)
+		}
 		return a, nil
 
+	case sessionImportedMsg:
+		a.restoreSession(msg.session)
+		return a, tea.Batch(textinput.Blink, a.loadVersions())
+
 	case pipelineErrorMsg:
-		a.state.processingError = msg.error
+		a.state.activePipeline = nil
 		a.view = viewDocument
+		if errors.Is(msg.error, context.Canceled) {
+			a.state.processingError = nil
+			return a, nil
+		}
+		a.state.processingError = msg.error
 		return a, nil
 
 	case skillGeneratedMsg:
@@ -213,6 +329,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.state.lastCreatedSkill = msg.skillName
 		// Reload skill index
 		a.state.skillIndex, _ = skill.NewSkillIndex()
+		a.advanceTour(tourStepSkill)
 		a.view = viewSkills
 		return a, nil
 
@@ -238,10 +355,33 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			role:    "assistant",
 			content: a.state.chatResult,
 		})
+		a.advanceTour(tourStepChat)
 		a.state.input.Focus()
 		return a, textinput.Blink
 
+	case chatSummaryMsg:
+		a.state.chatSummarizing = false
+		a.state.chatSummarizedAt = len(a.state.chatHistory)
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				a.state.docError = msg.err
+			}
+			a.state.input.Focus()
+			return a, nil
+		}
+		a.leaveChat()
+		a.state.notice = i18n.T("notice.chat_summary_saved")
+		return a, tea.Batch(textinput.Blink, loadChatSummaries())
+
+	case chatSummariesMsg:
+		a.state.chatSummaries = msg.entries
+		return a, nil
+
 	case chatErrorMsg:
+		if errors.Is(msg.error, context.Canceled) {
+			// Esc: keep what arrived as the reply
+			return a.Update(chatDoneMsg{})
+		}
 		a.state.chatStreaming = false
 		a.state.docError = msg.error
 		return a, nil
@@ -273,8 +413,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.state.apiKeyInput, cmd = a.state.apiKeyInput.Update(msg)
 		cmds = append(cmds, cmd)
 	} else if a.view == viewWelcome || a.view == viewDocument || a.view == viewResult || a.view == viewNewSkill || a.view == viewChat {
-		// Skip input update if palette is handling navigation keys
-		skipInput := false
+		// Skip input update if palette is handling navigation keys, or a
+		// suggestion key just submitted the instruction
+		skipInput := a.view == viewDocument && a.state.parsingIntent
 		if a.state.cmdPaletteActive && a.view == viewWelcome {
 			if keyMsg, ok := msg.(tea.KeyMsg); ok {
 				switch keyMsg.String() {
@@ -337,6 +478,26 @@ func (a *App) handleKey(msg tea.KeyMsg) tea.Cmd {
 		}
 	}
 
+	// Summary offer when leaving a long chat
+	if a.view == viewChat && a.state.chatExitPrompt {
+		switch msg.String() {
+		case "y":
+			a.state.chatExitPrompt = false
+			a.state.chatSummarizing = true
+			return a.summarizeChat()
+		case "n":
+			a.state.chatExitPrompt = false
+			a.state.chatSummarizedAt = len(a.state.chatHistory)
+			a.leaveChat()
+			return nil
+		}
+	}
+
+	if a.state.tourActive && msg.String() == "ctrl+t" {
+		a.state.tourActive = false
+		return nil
+	}
+
 	switch {
 	case key.Matches(msg, keys.Quit):
 		if a.state.cmdPaletteActive {
@@ -355,25 +516,38 @@ func (a *App) handleKey(msg tea.KeyMsg) tea.Cmd {
 			// Go back to welcome
 			a.view = viewWelcome
 			a.state.input.Reset()
-			a.state.input.Placeholder = "/help for commands, or drop a file..."
+			a.state.input.Placeholder = i18n.T("welcome.placeholder")
 			return nil
 		}
 		if a.view == viewHelp || a.view == viewSkills || a.view == viewNewSkill {
 			a.view = viewWelcome
 			a.state.input.Reset()
-			a.state.input.Placeholder = "/help for commands, or drop a file..."
+			a.state.input.Placeholder = i18n.T("welcome.placeholder")
+			return nil
+		}
+		if a.view == viewProcessing && a.state.activePipeline != nil {
+			a.state.activePipeline.Cancel()
+			return nil
+		}
+		if a.view == viewResult && a.state.streaming && a.state.activeWriter != nil {
+			a.state.activeWriter.Cancel()
 			return nil
 		}
 		if a.view == viewChat {
-			if a.state.chatStreaming {
-				// TODO: cancel streaming
+			if a.state.chatStreaming || a.state.chatSummarizing {
+				a.state.chatCancel.Cancel()
 				return nil
 			}
-			a.state.chatSkill = nil       // Clear active skill
-			a.state.chatScrollOffset = 0  // Reset scroll
-			a.view = viewWelcome
-			a.state.input.Reset()
-			a.state.input.Placeholder = "/help for commands, or drop a file..."
+			if a.state.chatExitPrompt {
+				// Esc again leaves without saving
+				a.state.chatExitPrompt = false
+				a.state.chatSummarizedAt = len(a.state.chatHistory)
+			} else if a.shouldOfferChatSummary() {
+				a.state.chatExitPrompt = true
+				a.state.input.Blur()
+				return nil
+			}
+			a.leaveChat()
 			return nil
 		}
 		if a.view == viewSetup && a.state.setupStep == 1 {
@@ -388,28 +562,31 @@ func (a *App) handleKey(msg tea.KeyMsg) tea.Cmd {
 	case key.Matches(msg, keys.Enter):
 		if a.view == viewWelcome && a.state.providerReady {
 			a.state.cmdPaletteActive = false
+			if a.state.tourActive && a.state.tourStep == tourStepLoad && strings.TrimSpace(a.state.input.Value()) == "" {
+				a.state.loadingDoc = true
+				a.state.documentPath = tour.SampleURI
+				return a.loadDocument(tour.SampleURI)
+			}
 			return a.handleInput()
 		}
+		if a.view == viewDocument && !a.state.anonymizing && strings.EqualFold(strings.TrimSpace(a.state.input.Value()), "/anonymize") {
+			return a.anonymizeDocument()
+		}
 		if a.view == viewDocument && a.state.providerReady {
 			instruction := strings.TrimSpace(a.state.input.Value())
 			if instruction != "" {
-				a.state.parsingIntent = true
-				a.state.input.Reset()
-				return a.parseIntent(instruction)
+				return a.submitDocumentInstruction(instruction)
 			}
 		}
 		// Handle result view follow-up
 		if a.view == viewResult && !a.state.streaming {
 			instruction := strings.TrimSpace(a.state.input.Value())
-			if instruction != "" {
-				// Add user message to history
-				a.state.history = append(a.state.history, message{
-					role:    "user",
-					content: instruction,
-				})
-				a.state.isFollowUp = true
+			if cmd := strings.ToLower(instruction); cmd == "/send" || strings.HasPrefix(cmd, "/send ") {
 				a.state.input.Reset()
-				return a.parseIntent(instruction)
+				return a.sendResult(strings.TrimSpace(instruction[len("/send"):]))
+			}
+			if instruction != "" {
+				return a.submitFollowUp(instruction)
 			}
 		}
 		// Handle new skill creation
@@ -425,7 +602,13 @@ func (a *App) handleKey(msg tea.KeyMsg) tea.Cmd {
 		// Handle chat view follow-up
 		if a.view == viewChat && !a.state.chatStreaming {
 			userMsg := strings.TrimSpace(a.state.input.Value())
+			if a.handlePinCommand(userMsg) {
+				return nil
+			}
 			if userMsg != "" {
+				if a.chatBlocked() {
+					return nil
+				}
 				a.state.chatHistory = append(a.state.chatHistory, message{
 					role:    "user",
 					content: userMsg,
@@ -446,13 +629,20 @@ func (a *App) handleKey(msg tea.KeyMsg) tea.Cmd {
 			a.state.document = nil
 			a.state.documentPath = ""
 			a.state.docError = nil
+			a.state.largeDoc = nil
+			a.state.queued = "" // Meant for the dropped document
 			a.state.currentIntent = nil
 			a.state.pipelineResult = nil
 			a.state.result = ""
+			a.state.resultIsPlan = false
+			a.state.anonymized = nil
+			a.state.notice = ""
+			a.state.versions = nil
+			a.state.versionIndex = -1
 			a.state.history = nil      // Clear history
 			a.state.isFollowUp = false // Reset flag
 			a.state.input.Reset()
-			a.state.input.Placeholder = "/help for commands, or drop a file..."
+			a.state.input.Placeholder = i18n.T("welcome.placeholder")
 			a.view = viewWelcome
 			return nil
 		}
@@ -460,23 +650,105 @@ func (a *App) handleKey(msg tea.KeyMsg) tea.Cmd {
 			a.state.chatHistory = nil
 			a.state.chatResult = ""
 			a.state.chatSkill = nil // Clear active skill
+			a.state.pinned = nil    // Clear pinned facts
 			a.state.lastStats = ""  // Clear stats
 			a.state.contextUsed = 0
 			a.state.streamTokens = 0
+			a.state.chatSummarizedAt = 0
 			a.state.input.Reset()
-			a.state.input.Placeholder = "/help for commands, or drop a file..."
+			a.state.input.Placeholder = i18n.T("welcome.placeholder")
 			a.view = viewWelcome
 			return nil
 		}
 	}
 
-	// Handle result view keys
-	if a.view == viewResult && !a.state.streaming {
+	// Large document confirmation
+	if a.view == viewDocument && a.state.largeDoc != nil {
+		switch msg.String() {
+		case "h":
+			a.state.hierarchical = true
+		case "y":
+			a.state.hierarchical = false
+		default:
+			return nil
+		}
+		a.state.largeDoc = nil
+		a.state.input.Focus()
+		if cmd := a.runQueued(); cmd != nil {
+			return cmd
+		}
+		return textinput.Blink
+	}
+
+	// One-tap suggestions on the document view (alt+1-4, so a typed
+	// "3 bullet points" stays an instruction)
+	if a.view == viewDocument && a.state.providerReady && !a.state.parsingIntent {
+		k := msg.String()
+		if len(k) == 5 && strings.HasPrefix(k, "alt+") && k[4] >= '1' && k[4] <= '9' {
+			if idx := int(k[4] - '1'); idx < len(a.state.suggestions) {
+				return a.submitDocumentInstruction(a.state.suggestions[idx])
+			}
+		}
+	}
+
+	// Dry run result: run the planned instruction for real, once; dry
+	// run stays on for the next instruction
+	if a.view == viewResult && a.state.resultIsPlan && msg.String() == "r" {
+		a.state.input.Focus()
+		if a.state.dryRunFollowUp {
+			return a.runFollowUp(a.state.dryRunInstruction)
+		}
+		return a.runInstruction(a.state.dryRunInstruction)
+	}
+
+	// Tour: continue from the result to skill creation
+	if a.view == viewResult && !a.state.streaming && a.state.tourActive && a.state.tourStep == tourStepSkill && msg.String() == "tab" {
+		a.view = viewNewSkill
+		a.state.input.Reset()
+		a.state.input.Placeholder = i18n.T("newskill.placeholder")
+		a.state.input.Focus()
+		return nil
+	}
+
+	// Handle result view keys (only when input is empty, like welcome shortcuts)
+	if a.view == viewResult && !a.state.streaming && a.state.input.Value() == "" {
 		switch msg.String() {
 		case "c":
 			return copyToClipboard(a.state.result)
 		case "s":
-			return saveToFile(a.state.result, a.state.document.Metadata.Title)
+			return a.sendResult("")
+		case "e":
+			if !a.state.resultIsPlan && a.state.anonymized == nil {
+				return a.exportSession()
+			}
+		case "p":
+			if !a.state.resultIsPlan && a.state.anonymized == nil {
+				return a.publishSession()
+			}
+		case "u":
+			if a.state.anonymized != nil {
+				return a.useAnonymized()
+			}
+		case "v":
+			if !a.state.resultIsPlan && a.state.anonymized == nil {
+				a.toggleVerify()
+				return nil
+			}
+		case "[":
+			if a.state.anonymized != nil {
+				return nil
+			}
+			if a.state.versionIndex < 0 {
+				a.showVersion(len(a.state.versions) - 1)
+			} else {
+				a.showVersion(a.state.versionIndex - 1)
+			}
+			return nil
+		case "]":
+			if a.state.versionIndex >= 0 && a.state.anonymized == nil {
+				a.showVersion(a.state.versionIndex + 1)
+			}
+			return nil
 		}
 	}
 
@@ -570,17 +842,29 @@ func (a *App) updateCommandPalette() {
 
 	// Build command list
 	commands := []cmdItem{
-		{"/help", "Show help"},
-		{"/settings", "Open settings"},
-		{"/skills", "List installed skills"},
-		{"/new-skill", "Create a new skill"},
-		{"/quit", "Exit pulp"},
+		{"/help", i18n.T("palette.help")},
+		{"/settings", i18n.T("palette.settings")},
+		{"/skills", i18n.T("palette.skills")},
+		{"/new-skill", i18n.T("palette.new_skill")},
+		{"/import", i18n.T("palette.import")},
+		{"/dry-run", i18n.T("palette.dry_run")},
+		{"/tour", i18n.T("palette.tour")},
+		{"/recall", i18n.T("palette.recall")},
+		{"/quit", i18n.T("palette.quit")},
+	}
+
+	// Saved chat summaries, newest first
+	for i, e := range a.state.chatSummaries {
+		if i == 5 {
+			break
+		}
+		commands = append(commands, cmdItem{fmt.Sprintf("/recall %d", i+1), truncate(e.Title, 50)})
 	}
 
 	// Add skill commands
 	if a.state.skillIndex != nil {
 		for _, name := range a.state.skillIndex.List() {
-			desc := "Use skill"
+			desc := i18n.T("palette.use_skill")
 			if meta := a.state.skillIndex.Get(name); meta != nil && meta.Description != "" {
 				desc = meta.Description
 				if len(desc) > 50 {
@@ -640,13 +924,36 @@ func (a *App) handleInput() tea.Cmd {
 				// Show skill creation view for input
 				a.view = viewNewSkill
 				a.state.input.Reset()
-				a.state.input.Placeholder = "Describe the skill you want to create..."
+				a.state.input.Placeholder = i18n.T("newskill.placeholder")
 				return nil
 			}
 			// Generate skill directly
 			a.state.input.Reset()
 			a.state.generatingSkill = true
 			return a.generateSkill(desc)
+		case cmd == "/import" || strings.HasPrefix(cmd, "/import "):
+			path := cleanFilePath(strings.TrimSpace(input[len("/import"):]))
+			if path == "" {
+				a.state.docError = fmt.Errorf("%s", i18n.T("command.import_usage", session.BundleExt))
+				a.state.input.Reset()
+				return nil
+			}
+			a.state.loadingDoc = true
+			a.state.docError = nil
+			a.state.input.Reset()
+			return importSession(path)
+		case cmd == "/recall" || strings.HasPrefix(cmd, "/recall "):
+			a.state.input.Reset()
+			a.recallChat(strings.TrimSpace(input[len("/recall"):]))
+			return nil
+		case cmd == "/tour":
+			a.startTour()
+			a.state.input.Reset()
+			return nil
+		case cmd == "/dry-run":
+			a.state.dryRun = !a.state.dryRun
+			a.state.input.Reset()
+			return nil
 		case cmd == "/quit" || cmd == "/q":
 			a.quitting = true
 			return tea.Quit
@@ -658,10 +965,29 @@ func (a *App) handleInput() tea.Cmd {
 
 			if a.state.skillIndex != nil {
 				if meta := a.state.skillIndex.Get(skillName); meta != nil {
+					// /skill-name <file> [instruction]: load the file and
+					// run the skill on it in one step
+					if len(parts) > 1 {
+						path, rest, err := splitAttachment(parts[1])
+						if err != nil {
+							a.state.docError = err
+							a.state.input.Reset()
+							return nil
+						}
+						if path != "" {
+							a.state.queued = strings.TrimSpace("/" + skillName + " " + rest)
+							a.state.loadingDoc = true
+							a.state.docError = nil
+							a.state.documentPath = path
+							a.state.input.Reset()
+							return a.loadDocument(path)
+						}
+					}
+
 					// Load full skill
 					fullSkill, err := skill.LoadFull(meta)
 					if err != nil {
-						a.state.docError = fmt.Errorf("failed to load skill: %v", err)
+						a.state.docError = fmt.Errorf("%s", i18n.T("command.skill_failed", err))
 						a.state.input.Reset()
 						return nil
 					}
@@ -670,7 +996,7 @@ func (a *App) handleInput() tea.Cmd {
 					a.state.input.Reset()
 
 					// If message provided, start chat immediately
-					if len(parts) > 1 && strings.TrimSpace(parts[1]) != "" {
+					if len(parts) > 1 && strings.TrimSpace(parts[1]) != "" && !a.chatBlocked() {
 						userMsg := strings.TrimSpace(parts[1])
 						a.state.chatHistory = append(a.state.chatHistory, message{
 							role:    "user",
@@ -686,7 +1012,7 @@ func (a *App) handleInput() tea.Cmd {
 
 					// Just activate skill, go to chat view
 					a.view = viewChat
-					a.state.input.Placeholder = fmt.Sprintf("Chat with %s skill...", fullSkill.Name)
+					a.state.input.Placeholder = i18n.T("chat.skill_placeholder", fullSkill.Name)
 					return nil
 				}
 			}
@@ -697,7 +1023,7 @@ func (a *App) handleInput() tea.Cmd {
 				break
 			}
 			// Unknown command
-			a.state.docError = fmt.Errorf("unknown command: %s (try /help)", input)
+			a.state.docError = fmt.Errorf("%s", i18n.T("command.unknown", input))
 			a.state.input.Reset()
 			return nil
 		}
@@ -705,6 +1031,9 @@ func (a *App) handleInput() tea.Cmd {
 
 	// Check if input looks like a file path
 	if !looksLikeFilePath(input) {
+		if a.chatBlocked() {
+			return nil
+		}
 		// Start general chat mode
 		a.state.chatHistory = append(a.state.chatHistory, message{
 			role:    "user",
@@ -721,9 +1050,12 @@ func (a *App) handleInput() tea.Cmd {
 
 	// Handle file path input
 	a.state.loadingDoc = true
-	a.state.documentPath = input
 	a.state.docError = nil
 	a.state.input.Reset()
+	if session.IsBundle(input) {
+		return importSession(input)
+	}
+	a.state.documentPath = input
 	return a.loadDocument(input)
 }
 
@@ -746,17 +1078,66 @@ func looksLikeFilePath(input string) bool {
 	}
 
 	// Has common document extensions
-	lower := strings.ToLower(check)
-	extensions := []string{".pdf", ".txt", ".md", ".doc", ".docx", ".html", ".htm", ".rtf", ".odt"}
+	return hasDocumentExtension(check)
+}
+
+// hasDocumentExtension checks if path ends in a document extension
+func hasDocumentExtension(path string) bool {
+	lower := strings.ToLower(path)
+	extensions := []string{".pdf", ".txt", ".md", ".doc", ".docx", ".html", ".htm", ".rtf", ".odt", ".eml", session.BundleExt}
+	extensions = append(extensions, converter.AudioExtensions...)
 	for _, ext := range extensions {
 		if strings.HasSuffix(lower, ext) {
 			return true
 		}
 	}
-
 	return false
 }
 
+// splitAttachment finds a document path at the start of a skill
+// command's argument: the whole argument, or its first word followed by
+// an instruction. Chat messages that merely contain a slash return no
+// path and go to the skill chat; a path to a document that doesn't exist
+// returns an error.
+func splitAttachment(arg string) (path, rest string, err error) {
+	candidates := [][2]string{{arg, ""}}
+	if first, after, found := strings.Cut(strings.TrimSpace(arg), " "); found {
+		candidates = append(candidates, [2]string{first, after})
+	}
+	var missing string
+	for _, c := range candidates {
+		path := cleanFilePath(c[0])
+		if !looksLikeFilePath(path) || session.IsBundle(path) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, strings.TrimSpace(c[1]), nil
+		}
+		// Only a document extension or a path prefix without spaces makes
+		// it a path rather than a message
+		if missing == "" && os.IsNotExist(err) && (hasDocumentExtension(path) || (!strings.Contains(path, " ") && strings.ContainsAny(path[:1], "/.~"))) {
+			missing = path
+		}
+	}
+	if missing != "" {
+		return "", "", fmt.Errorf("%s", i18n.T("command.file_not_found", missing))
+	}
+	return "", "", nil
+}
+
+// runQueued starts the instruction queued by /skill-name <file> once
+// the document is loaded and confirmed
+func (a *App) runQueued() tea.Cmd {
+	instruction := a.state.queued
+	if instruction == "" || !a.state.providerReady {
+		return nil
+	}
+	a.state.queued = ""
+	a.state.isFollowUp = false
+	return a.submitDocumentInstruction(instruction)
+}
+
 // cleanFilePath handles various drag-and-drop path formats
 func cleanFilePath(input string) string {
 	input = strings.TrimSpace(input)
@@ -793,14 +1174,9 @@ func cleanFilePath(input string) string {
 }
 
 func (a *App) loadDocument(path string) tea.Cmd {
+	opts := converter.LoadOptions{Transcription: a.state.config.TranscriptionSettings()}
 	return func() tea.Msg {
-		converter, err := converter.NewConverter()
-		if err != nil {
-			return documentErrorMsg{err}
-		}
-
-		ctx := context.Background()
-		doc, err := converter.Convert(ctx, path)
+		doc, err := converter.Load(context.Background(), path, opts)
 		if err != nil {
 			return documentErrorMsg{err}
 		}
@@ -809,6 +1185,129 @@ func (a *App) loadDocument(path string) tea.Cmd {
 	}
 }
 
+// showDryRun shows the calls and prompts a run would make, without
+// calling the LLM
+func (a *App) showDryRun(instruction string) {
+	a.showPlan(dryrun.Build(a.state.document, a.dryRunOptions(instruction)), instruction)
+	a.state.dryRunFollowUp = false
+}
+
+// showFollowUpDryRun shows the calls a follow-up on the result would
+// make, without calling the LLM
+func (a *App) showFollowUpDryRun(instruction string) {
+	history := append(append([]message(nil), a.state.history...), message{role: "user", content: instruction})
+	plan := dryrun.BuildFollowUp(a.dryRunOptions(instruction), func(in *intent.Intent) *writer.WriteRequest {
+		return a.writeRequest(in, history, true)
+	})
+	a.showPlan(plan, instruction)
+	a.state.dryRunFollowUp = true
+}
+
+func (a *App) dryRunOptions(instruction string) dryrun.Options {
+	gen := a.state.config.GenerationSettings()
+	return dryrun.Options{
+		Model:        a.state.config.Model,
+		Instruction:  instruction,
+		SkillIndex:   a.state.skillIndex,
+		Hierarchical: a.state.hierarchical,
+		Language:     a.state.config.ExtractionLanguage,
+		Preamble:     a.state.config.Preamble,
+		Generation:   &gen,
+	}
+}
+
+func (a *App) showPlan(plan *pipeline.Plan, instruction string) {
+	a.state.input.Reset()
+	a.state.input.Blur()
+	a.state.result = plan.Report()
+	a.state.resultIsPlan = true
+	a.state.anonymized = nil
+	a.state.dryRunInstruction = instruction
+	a.state.notice = ""
+	a.view = viewResult
+}
+
+// checkDocumentSize returns size info if the document exceeds the
+// configured thresholds, or nil if it can be processed directly
+func (a *App) checkDocumentSize(doc *converter.Document) *largeDocInfo {
+	limits := a.state.config.DocLimits()
+	meta := doc.Metadata
+
+	pages := 0
+	if meta.PageCount != nil {
+		pages = *meta.PageCount
+	}
+	if !limits.Exceeds(meta.WordCount, pages) {
+		return nil
+	}
+
+	return &largeDocInfo{
+		words:             meta.WordCount,
+		pages:             pages,
+		calls:             pipeline.EstimateCalls(doc.Content, false),
+		hierarchicalCalls: pipeline.EstimateCalls(doc.Content, true),
+	}
+}
+
+// submitDocumentInstruction starts processing the loaded document
+func (a *App) submitDocumentInstruction(instruction string) tea.Cmd {
+	if a.state.dryRun {
+		a.showDryRun(instruction)
+		return nil
+	}
+	return a.runInstruction(instruction)
+}
+
+// runInstruction processes the loaded document, even in dry-run mode
+func (a *App) runInstruction(instruction string) tea.Cmd {
+	a.state.resultIsPlan = false
+	a.state.anonymized = nil
+	a.state.history = append(a.state.history, message{
+		role:    "user",
+		content: instruction,
+	})
+	a.state.parsingIntent = true
+	a.state.input.Reset()
+
+	recordRecent := func() tea.Msg {
+		// Recent instructions only feed suggestions; failures don't matter
+		intent.RecordRecent(instruction)
+		return nil
+	}
+	return tea.Batch(a.parseIntent(instruction), recordRecent)
+}
+
+// submitFollowUp revises the result on screen with the instruction
+func (a *App) submitFollowUp(instruction string) tea.Cmd {
+	// Revise the draft on screen, even if it's an earlier version
+	a.state.revisionBase = ""
+	if v := a.viewingVersion(); v != nil {
+		a.state.revisionBase = v.Result
+	}
+	if a.state.dryRun {
+		// Nothing to revise yet when only a plan has been shown
+		if a.state.pipelineResult == nil {
+			a.showDryRun(instruction)
+		} else {
+			a.showFollowUpDryRun(instruction)
+		}
+		return nil
+	}
+	return a.runFollowUp(instruction)
+}
+
+// runFollowUp sends a follow-up to the writer, even in dry-run mode
+func (a *App) runFollowUp(instruction string) tea.Cmd {
+	a.state.resultIsPlan = false
+	a.state.history = append(a.state.history, message{
+		role:    "user",
+		content: instruction,
+	})
+	a.state.isFollowUp = true
+	a.state.input.Reset()
+	return a.parseIntent(instruction)
+}
+
 func (a *App) parseIntent(instruction string) tea.Cmd {
 	return func() tea.Msg {
 		parser := intent.NewParser(a.state.provider, a.state.config.Model, a.state.skillIndex)
@@ -839,9 +1338,12 @@ func (a *App) generateSkill(description string) tea.Cmd {
 }
 
 func (a *App) runPipeline() tea.Cmd {
-	return func() tea.Msg {
-		pipe := pipeline.NewPipeline(a.state.provider, a.state.config.Model)
+	pipe := pipeline.NewPipeline(a.state.provider, a.state.config.Model)
+	pipe.SetHierarchical(a.state.hierarchical)
+	pipe.SetLanguage(a.state.config.ExtractionLanguage)
+	a.state.activePipeline = pipe
 
+	return func() tea.Msg {
 		ctx := context.Background()
 		result, err := pipe.Process(ctx, a.state.document, a.state.currentIntent)
 		if err != nil {
@@ -852,42 +1354,74 @@ func (a *App) runPipeline() tea.Cmd {
 	}
 }
 
-func (a *App) startWriter() tea.Cmd {
-	return func() tea.Msg {
-		w := writer.NewWriter(a.state.provider, a.state.config.Model)
-
-		// Convert history to writer format
-		var history []writer.Message
-		for _, m := range a.state.history {
-			history = append(history, writer.Message{
-				Role:    m.role,
-				Content: m.content,
-			})
-		}
+// writeRequest builds the writer's request for the processed document.
+// A follow-up revises the previous result (or the earlier version it was
+// asked on) and brings in any section its instruction refers to.
+func (a *App) writeRequest(in *intent.Intent, history []message, followUp bool) *writer.WriteRequest {
+	var msgs []writer.Message
+	for _, m := range history {
+		msgs = append(msgs, writer.Message{
+			Role:    m.role,
+			Content: m.content,
+		})
+	}
 
-		// Get previous result for follow-ups
-		var previousResult string
-		if a.state.isFollowUp && len(a.state.history) > 0 {
-			// Find last assistant message
-			for i := len(a.state.history) - 1; i >= 0; i-- {
-				if a.state.history[i].role == "assistant" {
-					previousResult = a.state.history[i].content
-					break
-				}
+	// Get previous result for follow-ups
+	var previousResult string
+	if followUp && len(history) > 0 {
+		// Find last assistant message
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i].role == "assistant" {
+				previousResult = history[i].content
+				break
 			}
 		}
+		if a.state.revisionBase != "" {
+			previousResult = a.state.revisionBase
+		}
+	}
+
+	req := &writer.WriteRequest{
+		Aggregated:     a.state.pipelineResult.Aggregated,
+		Intent:         in,
+		DocTitle:       a.state.document.Metadata.Title,
+		History:        msgs,
+		IsFollowUp:     followUp,
+		PreviousResult: previousResult,
+	}
 
-		req := &writer.WriteRequest{
-			Aggregated:     a.state.pipelineResult.Aggregated,
-			Intent:         a.state.currentIntent,
-			DocTitle:       a.state.document.Metadata.Title,
-			History:        history,
-			IsFollowUp:     a.state.isFollowUp,
-			PreviousResult: previousResult,
+	// Resolve "expand on section 3" style references against the outline
+	if followUp {
+		if section := a.state.pipelineResult.Outline.Resolve(in.RawPrompt); section != nil {
+			req.Section = section
+			req.SectionChunks = section.Chunks(pipeline.DefaultChunkSize)
 		}
+	}
+
+	return req
+}
+
+func (a *App) startWriter() tea.Cmd {
+	w := writer.NewWriter(a.state.provider, a.state.config.Model)
+	gen := a.state.config.GenerationSettings()
+	w.SetParams(gen.Temperature, gen.MaxTokens)
+	w.SetPreamble(a.state.config.Preamble)
+	a.state.activeWriter = w
+	a.state.notice = a.capabilityNotice()
+
+	return func() tea.Msg {
+		req := a.writeRequest(a.state.currentIntent, a.state.history, a.state.isFollowUp)
 
 		ctx := context.Background()
-		stream, err := w.Stream(ctx, req)
+		var stream <-chan llm.StreamEvent
+		var err error
+		if gen.Stream {
+			stream, err = w.Stream(ctx, req)
+		} else {
+			var text string
+			text, err = w.Write(ctx, req)
+			stream = llm.SingleEvent(text)
+		}
 		if err != nil {
 			return streamErrorMsg{err}
 		}
@@ -924,32 +1458,79 @@ func (a *App) startWriter() tea.Cmd {
 	}
 }
 
+// capabilityNotice explains where the model falls short of the
+// generation settings: no streaming, or a lower output token limit
+func (a *App) capabilityNotice() string {
+	caps := llm.CapabilitiesOf(a.state.provider)
+	gen := a.state.config.GenerationSettings()
+	model := a.state.config.Model
+	switch {
+	case gen.Stream && !caps.Streaming:
+		return i18n.T("notice.no_streaming", model)
+	case caps.OutputTokens(gen.MaxTokens) < gen.MaxTokens:
+		return i18n.T("notice.max_tokens_capped", gen.MaxTokens, model, caps.MaxOutputTokens)
+	}
+	return ""
+}
+
+// streamOrComplete streams req, or with streaming turned off in settings
+// (or unsupported by the model) makes one Complete call and delivers the
+// response as a single chunk
+func (a *App) streamOrComplete(ctx context.Context, req *llm.CompletionRequest) (<-chan llm.StreamEvent, error) {
+	if a.state.config.GenerationSettings().Stream {
+		return llm.StreamOrComplete(ctx, a.state.provider, req)
+	}
+
+	resp, err := a.state.provider.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return llm.SingleEvent(resp.Content), nil
+}
+
 func copyToClipboard(content string) tea.Cmd {
 	return func() tea.Msg {
-		// For simplicity, just return success
-		// A real implementation would use OS-specific clipboard commands
-		return clipboardMsg{success: true}
+		sink, err := output.Open(nil, "clipboard")
+		if err == nil {
+			_, err = sink.Send(context.Background(), output.Result{Content: content})
+		}
+		return clipboardMsg{success: err == nil, err: err}
 	}
 }
 
-func saveToFile(content, title string) tea.Cmd {
+// sendResult delivers the result on screen to an output sink: a name
+// from outputs: in the config, a sink type, or the default output when
+// target is empty
+func (a *App) sendResult(target string) tea.Cmd {
+	cfg := a.state.config
+	res := output.Result{Content: a.state.result}
+	if a.state.anonymized != nil {
+		res.Title = a.state.anonymized.Metadata.Title
+	} else if a.state.document != nil {
+		res.Title = a.state.document.Metadata.Title
+	}
 	return func() tea.Msg {
-		// Generate filename
-		filename := strings.ReplaceAll(title, " ", "_") + "_summary.md"
-		home, _ := os.UserHomeDir()
-		path := filepath.Join(home, "Documents", filename)
-
-		// Ensure directory exists
-		os.MkdirAll(filepath.Dir(path), 0755)
-
-		// Write file
-		err := os.WriteFile(path, []byte(content), 0644)
+		sink, err := output.Open(cfg, target)
 		if err != nil {
 			return saveMsg{err: err}
 		}
+		// Printing would scribble over the screen
+		if output.IsStdout(sink) {
+			return saveMsg{err: errors.New(i18n.T("notice.stdout_in_tui"))}
+		}
+		where, err := sink.Send(context.Background(), res)
+		return saveMsg{path: where, err: err}
+	}
+}
 
-		return saveMsg{path: path}
+// chatBlocked reports whether dry run is on, which keeps chat from
+// calling the LLM, and says so
+func (a *App) chatBlocked() bool {
+	if !a.state.dryRun {
+		return false
 	}
+	a.state.docError = errors.New(i18n.T("chat.dry_run"))
+	return true
 }
 
 func (a *App) startChat(userMessage string) tea.Cmd {
@@ -970,16 +1551,19 @@ func (a *App) startChat(userMessage string) tea.Cmd {
 			})
 		}
 
-		ctx := context.Background()
-		stream, err := a.state.provider.Stream(ctx, &llm.CompletionRequest{
+		ctx, done := a.state.chatCancel.Start(context.Background())
+		gen := a.state.config.GenerationSettings()
+		stream, err := a.streamOrComplete(ctx, &llm.CompletionRequest{
 			Model:       a.state.config.Model,
 			Messages:    messages,
-			MaxTokens:   2000,
-			Temperature: 0.7,
+			MaxTokens:   llm.CapabilitiesOf(a.state.provider).OutputTokens(gen.MaxTokens),
+			Temperature: gen.Temperature,
 		})
 		if err != nil {
+			done()
 			return chatErrorMsg{err}
 		}
+		stream = llm.Relay(ctx, stream, done)
 
 		// Stream chunks via program.Send
 		go func() {
@@ -1020,7 +1604,11 @@ func (a *App) buildChatSystemPrompt() string {
 		skillName = a.state.chatSkill.Name
 		skillBody = a.state.chatSkill.Body
 	}
-	return prompts.BuildChatPrompt(skillName, skillBody)
+	prompt := prompts.WithPreamble(a.state.config.Preamble, prompts.BuildChatPrompt(skillName, skillBody))
+	if pinned := prompts.BuildPinnedContext(a.state.pinned); pinned != "" {
+		prompt += "\n\n---\n\n" + pinned
+	}
+	return prompt
 }
 
 // initStreamStats initializes streaming statistics before starting a chat
@@ -1033,13 +1621,7 @@ func (a *App) initStreamStats() {
 	a.state.chatScrollOffset = 0    // Scroll to bottom
 	a.state.chatAutoScroll = true   // Enable auto-scroll
 
-	// Calculate input context (system prompt + history)
-	systemPrompt := a.buildChatSystemPrompt()
-	inputTokens := estimateTokens(systemPrompt)
-	for _, m := range a.state.chatHistory {
-		inputTokens += estimateTokens(m.content)
-	}
-	a.state.contextUsed = inputTokens
+	a.updateContextUsed()
 
 	// Get context limit from model
 	model := ""
@@ -1049,6 +1631,17 @@ func (a *App) initStreamStats() {
 	a.state.contextLimit = getContextLimit(model)
 }
 
+// updateContextUsed recalculates input context (system prompt, pins
+// and history)
+func (a *App) updateContextUsed() {
+	systemPrompt := a.buildChatSystemPrompt()
+	inputTokens := estimateTokens(systemPrompt)
+	for _, m := range a.state.chatHistory {
+		inputTokens += estimateTokens(m.content)
+	}
+	a.state.contextUsed = inputTokens
+}
+
 func (a *App) handleSetupKey(msg tea.KeyMsg) tea.Cmd {
 	switch a.state.setupStep {
 	case 0: // Provider selection
@@ -1092,6 +1685,10 @@ func (a *App) finishSetup() tea.Cmd {
 		if err := a.state.config.Save(); err != nil {
 			return setupErrorMsg{err}
 		}
+		// First run: add the demo skill so skills work out of the box
+		if a.state.skillIndex != nil {
+			samples.InstallSkills(a.state.skillIndex.SkillsDir())
+		}
 		return setupCompleteMsg{}
 	}
 }
@@ -1125,6 +1722,11 @@ func (a *App) handleSettingsKey(msg tea.KeyMsg) tea.Cmd {
 				}
 			}
 			return nil
+		case "g":
+			a.state.settingsMode = "generation"
+			a.state.settingsSelected = 0
+			a.state.genDraft = a.state.config.GenerationSettings()
+			return nil
 		case "k":
 			a.state.settingsMode = "apikey"
 			a.state.apiKeyInput.SetValue("")
@@ -1180,6 +1782,10 @@ func (a *App) handleSettingsKey(msg tea.KeyMsg) tea.Cmd {
 			if a.state.settingsSelected < len(provider.Models) {
 				a.state.config.Model = provider.Models[a.state.settingsSelected]
 				a.state.config.Save()
+				// Rebuild the provider so its capabilities match the model
+				if p, err := a.newProvider(); err == nil {
+					a.state.provider = p
+				}
 			}
 			a.state.settingsMode = ""
 			return nil
@@ -1188,6 +1794,26 @@ func (a *App) handleSettingsKey(msg tea.KeyMsg) tea.Cmd {
 			return nil
 		}
 
+	case "generation":
+		switch msg.String() {
+		case "up", "k":
+			if a.state.settingsSelected > 0 {
+				a.state.settingsSelected--
+			}
+		case "down", "j":
+			if a.state.settingsSelected < len(generationFields)-1 {
+				a.state.settingsSelected++
+			}
+		case "left", "h", "-":
+			adjustGeneration(&a.state.genDraft, a.state.settingsSelected, -1)
+		case "right", "l", "+", " ":
+			adjustGeneration(&a.state.genDraft, a.state.settingsSelected, 1)
+		case "enter":
+			a.state.config.SetGenerationSettings(a.state.genDraft)
+			a.state.config.Save()
+			a.state.settingsMode = ""
+		}
+
 	case "apikey":
 		switch msg.String() {
 		case "enter":
@@ -1210,6 +1836,16 @@ func (a *App) handleSettingsKey(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+type chatSummaryMsg struct {
+	id  string
+	err error
+}
+type chatSummariesMsg struct{ entries []session.Entry }
+type versionsMsg struct {
+	key      string
+	versions []session.Version
+	latest   bool // A result was just saved; show it as the newest version
+}
 type setupCompleteMsg struct{}
 type setupErrorMsg struct{ error }
 type providerReadyMsg struct{}
@@ -1218,6 +1854,12 @@ type documentLoadedMsg struct {
 	doc *converter.Document
 }
 type documentErrorMsg struct{ error }
+type anonymizedMsg struct {
+	doc          *converter.Document
+	count        int
+	patternsOnly bool // No local model found the names
+	err          error
+}
 type intentParsedMsg struct {
 	intent *intent.Intent
 }
@@ -1245,6 +1887,13 @@ type saveMsg struct {
 	path string
 	err  error
 }
+type publishedMsg struct {
+	id  string
+	err error
+}
+type sessionImportedMsg struct {
+	session *session.Session
+}
 type skillGeneratedMsg struct {
 	skillName string
 }
@@ -1272,28 +1921,40 @@ func (a *App) View() string {
 		return ""
 	}
 
+	// The tour hint sits above the view, which gets the remaining height
+	if a.state.tourActive && a.view != viewSetup {
+		banner := a.renderTourBanner()
+		height := a.height - lipgloss.Height(banner) - 1 // And a blank line
+		return banner + "\n\n" + a.renderView(height)
+	}
+
+	return a.renderView(a.height)
+}
+
+// renderView renders the current view to fit height lines
+func (a *App) renderView(height int) string {
 	switch a.view {
 	case viewWelcome:
-		return a.renderWelcome()
+		return a.renderWelcome(height)
 	case viewSetup:
-		return a.renderSetup()
+		return a.renderSetup(height)
 	case viewDocument:
-		return a.renderDocument()
+		return a.renderDocument(height)
 	case viewProcessing:
-		return a.renderProcessing()
+		return a.renderProcessing(height)
 	case viewResult:
-		return a.renderResult()
+		return a.renderResult(height)
 	case viewSettings:
-		return a.renderSettings()
+		return a.renderSettings(height)
 	case viewHelp:
-		return a.renderHelp()
+		return a.renderHelp(height)
 	case viewSkills:
-		return a.renderSkills()
+		return a.renderSkills(height)
 	case viewNewSkill:
-		return a.renderNewSkill()
+		return a.renderNewSkill(height)
 	case viewChat:
-		return a.renderChat()
+		return a.renderChat(height)
 	default:
-		return a.renderWelcome()
+		return a.renderWelcome(height)
 	}
 }
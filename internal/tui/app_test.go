@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/pipeline"
+)
+
+func TestSplitAttachment(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "standup notes.txt")
+	if err := os.WriteFile(file, []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	short := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(short, []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		arg, path, rest string
+		missing         bool
+	}{
+		{file, file, "", false},
+		{"'" + file + "'", file, "", false},
+		{short + " focus on blockers", short, "focus on blockers", false},
+		{"what does and/or mean here", "", "", false},
+		{dir, "", "", false},
+		{filepath.Join(dir, "missing.txt"), "", "", true},
+		{filepath.Join(dir, "missing") + " summarize it", "", "", true},
+		{"./notes.md focus on blockers", "", "", true},
+	}
+	for _, tt := range tests {
+		path, rest, err := splitAttachment(tt.arg)
+		if path != tt.path || rest != tt.rest || (err != nil) != tt.missing {
+			t.Errorf("splitAttachment(%q) = %q, %q, %v", tt.arg, path, rest, err)
+		}
+	}
+}
+
+func TestNewDocumentDropsQueuedInstruction(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	a := NewApp()
+	a.view = viewDocument
+	a.state.document = &converter.Document{Content: "big"}
+	a.state.largeDoc = &largeDocInfo{}
+	a.state.queued = "/summarize"
+	a.state.input.Blur()
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+
+	if a.view != viewWelcome || a.state.largeDoc != nil || a.state.queued != "" {
+		t.Errorf("after n: view %v, largeDoc %v, queued %q", a.view, a.state.largeDoc, a.state.queued)
+	}
+}
+
+func TestRunPlanKeepsDryRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	a := NewApp()
+	a.view = viewResult
+	a.state.dryRun = true
+	a.state.resultIsPlan = true
+	a.state.dryRunInstruction = "summarize"
+
+	if cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); cmd == nil {
+		t.Fatal("r on a plan didn't start processing")
+	}
+	if !a.state.parsingIntent || a.state.resultIsPlan || !a.state.dryRun {
+		t.Errorf("after r: parsing %v, plan %v, dry run %v", a.state.parsingIntent, a.state.resultIsPlan, a.state.dryRun)
+	}
+}
+
+func TestDryRunFollowUpAndChat(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	a := NewApp()
+	a.view = viewResult
+	a.state.dryRun = true
+	a.state.document = &converter.Document{Content: "Revenue grew."}
+	a.state.pipelineResult = &pipeline.Result{
+		Aggregated: &pipeline.AggregatedContent{},
+		Outline:    pipeline.BuildOutline("Revenue grew."),
+	}
+	a.state.history = []message{{role: "user", content: "summarize"}, {role: "assistant", content: "Revenue grew a lot."}}
+
+	a.state.input.SetValue("make it shorter")
+	if cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || a.state.parsingIntent {
+		t.Fatal("follow-up in dry run called the LLM")
+	}
+	if !a.state.resultIsPlan || !a.state.dryRunFollowUp || !strings.Contains(a.state.result, "Revenue grew a lot.") {
+		t.Errorf("follow-up plan = %q", a.state.result)
+	}
+	if len(a.state.history) != 2 {
+		t.Errorf("planning a follow-up added to the history: %v", a.state.history)
+	}
+
+	if cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); cmd == nil || !a.state.isFollowUp || a.state.resultIsPlan {
+		t.Error("r on a follow-up plan didn't run the follow-up")
+	}
+
+	a.view = viewChat
+	a.state.input.SetValue("hello")
+	if cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || a.state.chatStreaming || len(a.state.chatHistory) != 0 {
+		t.Error("chat in dry run called the LLM")
+	}
+}
+
+func TestSuggestionKeys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	a := NewApp()
+	a.view = viewDocument
+	a.state.providerReady = true
+	a.state.dryRun = true // Show the plan instead of calling the LLM
+	a.state.document = &converter.Document{Content: "Revenue grew."}
+	a.state.suggestions = []string{"summarize", "key points", "action items"}
+
+	// A typed digit starts an instruction
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	if a.state.resultIsPlan {
+		t.Fatal("typing 3 ran a suggestion")
+	}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3"), Alt: true})
+	if !a.state.resultIsPlan || a.state.dryRunInstruction != "action items" {
+		t.Errorf("alt+3 planned %q, want the third suggestion", a.state.dryRunInstruction)
+	}
+}
+
+func TestPinSelection(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	a := NewApp()
+	a.state.chatHistory = []message{{role: "assistant", content: "Options:\n- Raise prices 5%\n- Cut the free tier\n* Do nothing"}}
+
+	a.handlePinCommand("/pin 2")
+	a.handlePinCommand("/pin 4")
+	if len(a.state.pinned) != 1 || a.state.pinned[0] != "Cut the free tier" || a.state.docError == nil {
+		t.Errorf("pinned %q, error %v", a.state.pinned, a.state.docError)
+	}
+
+	if got := replyItems("Revenue grew 12%. Churn fell! Why? Unclear"); strings.Join(got, "|") != "Revenue grew 12%.|Churn fell!|Why?|Unclear" {
+		t.Errorf("replyItems() = %q", got)
+	}
+}
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/i18n"
+	"github.com/sant0-9/pulp/internal/llm"
+	"github.com/sant0-9/pulp/internal/prompts"
+	"github.com/sant0-9/pulp/internal/session"
+)
+
+// Chats with at least this many new messages get a summary offer on exit
+const longChatMessages = 6
+
+// shouldOfferChatSummary returns true if the chat grew long since the
+// last summary (or the last declined offer)
+func (a *App) shouldOfferChatSummary() bool {
+	return a.state.provider != nil && len(a.state.chatHistory)-a.state.chatSummarizedAt >= longChatMessages
+}
+
+// leaveChat returns from chat to the welcome screen
+func (a *App) leaveChat() {
+	a.state.chatSkill = nil      // Clear active skill
+	a.state.chatScrollOffset = 0 // Reset scroll
+	a.view = viewWelcome
+	a.state.input.Reset()
+	a.state.input.Placeholder = i18n.T("welcome.placeholder")
+	a.state.input.Focus()
+}
+
+// summarizeChat asks the LLM for a brief summary of the chat and saves
+// it, with the transcript, to the session store
+func (a *App) summarizeChat() tea.Cmd {
+	history := append([]message(nil), a.state.chatHistory...)
+	provider := a.state.provider
+	model := a.state.config.Model
+	canceler := &a.state.chatCancel
+
+	return func() tea.Msg {
+		var transcript strings.Builder
+		var title string
+		var conversation []session.Message
+		for _, m := range history {
+			role := "User"
+			if m.role == "assistant" {
+				role = "Pulp"
+			} else if title == "" {
+				title = strings.Join(strings.Fields(m.content), " ")
+			}
+			transcript.WriteString(fmt.Sprintf("%s: %s\n\n", role, m.content))
+			conversation = append(conversation, session.Message{Role: m.role, Content: m.content})
+		}
+
+		ctx, done := canceler.Start(context.Background())
+		defer done()
+		ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+		defer cancel()
+
+		resp, err := provider.Complete(ctx, &llm.CompletionRequest{
+			Model: model,
+			Messages: []llm.Message{
+				{Role: "system", Content: prompts.ChatSummary},
+				{Role: "user", Content: transcript.String()},
+			},
+			MaxTokens:   800,
+			Temperature: 0.3,
+		})
+		if err != nil {
+			return chatSummaryMsg{err: err}
+		}
+
+		store, err := session.NewStore()
+		if err != nil {
+			return chatSummaryMsg{err: err}
+		}
+		id, err := store.Save(&session.Session{
+			Kind:         session.KindChat,
+			Document:     converter.Metadata{Title: truncate(title, 60)},
+			Conversation: conversation,
+			Result:       strings.TrimSpace(resp.Content),
+		})
+		if err != nil {
+			return chatSummaryMsg{err: err}
+		}
+		return chatSummaryMsg{id: id}
+	}
+}
+
+// loadChatSummaries refreshes the saved summaries offered by /recall
+func loadChatSummaries() tea.Cmd {
+	return func() tea.Msg {
+		store, err := session.NewStore()
+		if err != nil {
+			return chatSummariesMsg{}
+		}
+		entries, _ := store.List(session.KindChat)
+		return chatSummariesMsg{entries}
+	}
+}
+
+// recallChat starts a new chat with a saved summary pinned as context.
+// arg is the 1-based position in the newest-first list (default 1).
+func (a *App) recallChat(arg string) {
+	n := 1
+	if arg != "" {
+		var err error
+		if n, err = strconv.Atoi(arg); err != nil {
+			n = 0
+		}
+	}
+	if len(a.state.chatSummaries) == 0 {
+		a.state.docError = fmt.Errorf("%s", i18n.T("recall.none"))
+		return
+	}
+	if n < 1 || n > len(a.state.chatSummaries) {
+		a.state.docError = fmt.Errorf("%s", i18n.T("recall.usage", len(a.state.chatSummaries)))
+		return
+	}
+
+	store, err := session.NewStore()
+	if err == nil {
+		var s *session.Session
+		if s, err = store.Load(a.state.chatSummaries[n-1].ID); err == nil {
+			a.state.chatHistory = nil
+			a.state.chatResult = ""
+			a.state.chatSkill = nil
+			a.state.chatSummarizedAt = 0
+			a.state.pinned = []string{i18n.T("recall.pinned", s.Document.Title, s.Result)}
+			a.state.docError = nil
+			a.updateContextUsed()
+			a.view = viewChat
+			a.state.input.Placeholder = i18n.T("recall.placeholder")
+			return
+		}
+	}
+	a.state.docError = err
+}
@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/llm/llmtest"
+)
+
+// The tests here run the whole app in a tea.Program against a fake
+// provider API: keys go in through Send, and the screen is read back
+// from inside the event loop so nothing races with Update.
+
+// viewRequest asks the probe for the current screen, ANSI stripped
+type viewRequest chan string
+
+// probe wraps App to answer viewRequests between messages
+type probe struct{ app *App }
+
+func (p probe) Init() tea.Cmd { return p.app.Init() }
+func (p probe) View() string  { return "" }
+
+func (p probe) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if reply, ok := msg.(viewRequest); ok {
+		reply <- ansi.Strip(p.app.View())
+		return p, nil
+	}
+	_, cmd := p.app.Update(msg)
+	return p, cmd
+}
+
+type tuiTest struct {
+	t    *testing.T
+	srv  *llmtest.Server
+	prog *tea.Program
+	done chan struct{}
+}
+
+// startTUI runs the app with HOME in a temp dir and every provider API
+// request going to srv. A nil config starts at first-run setup.
+func startTUI(t *testing.T, srv *llmtest.Server, cfg *config.Config) *tuiTest {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	if cfg != nil {
+		if err := cfg.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := NewApp()
+	app.httpClient = &http.Client{Transport: srv.Transport()}
+	prog := tea.NewProgram(probe{app},
+		tea.WithInput(nil),
+		tea.WithoutRenderer(),
+		tea.WithoutSignalHandler(),
+	)
+	app.SetProgram(prog)
+
+	tt := &tuiTest{t: t, srv: srv, prog: prog, done: make(chan struct{})}
+	go func() {
+		defer close(tt.done)
+		prog.Run()
+	}()
+	t.Cleanup(func() {
+		prog.Quit()
+		select {
+		case <-tt.done:
+		case <-time.After(2 * time.Second):
+			prog.Kill()
+		}
+	})
+
+	prog.Send(tea.WindowSizeMsg{Width: 120, Height: 50})
+	return tt
+}
+
+// fakeConfig is a configured custom provider; the fake serves any URL
+func fakeConfig() *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Provider = "custom"
+	cfg.BaseURL = "http://llm.test/v1"
+	cfg.Model = "fake-model"
+	cfg.APIKey = "test-key"
+	return cfg
+}
+
+func (tt *tuiTest) typeText(s string) {
+	tt.prog.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+}
+
+func (tt *tuiTest) press(k tea.KeyType) {
+	tt.prog.Send(tea.KeyMsg{Type: k})
+}
+
+func (tt *tuiTest) view() string {
+	reply := make(viewRequest, 1)
+	tt.prog.Send(reply)
+	return <-reply
+}
+
+// waitFor waits until the screen shows text and returns the screen
+func (tt *tuiTest) waitFor(text string) string {
+	tt.t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		screen := tt.view()
+		if strings.Contains(screen, text) {
+			return screen
+		}
+		if time.Now().After(deadline) {
+			tt.t.Fatalf("screen never showed %q; last screen:\n%s", text, screen)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSetupConnectsToProvider(t *testing.T) {
+	tt := startTUI(t, llmtest.NewServer(t), nil)
+	tt.waitFor("Choose your LLM provider")
+
+	for i, p := range config.Providers {
+		if p.ID == "openai" {
+			for j := 0; j < i; j++ {
+				tt.typeText("j")
+			}
+		}
+	}
+	tt.press(tea.KeyEnter)
+	tt.waitFor("Enter your OpenAI API key")
+	tt.typeText("sk-test")
+	tt.press(tea.KeyEnter)
+	tt.waitFor("Ready - ")
+
+	cfg, err := config.Load()
+	if err != nil || cfg == nil {
+		t.Fatalf("config.Load() = %v, %v", cfg, err)
+	}
+	if cfg.Provider != "openai" || cfg.APIKey != "sk-test" {
+		t.Errorf("saved config: provider %q, key %q", cfg.Provider, cfg.APIKey)
+	}
+}
+
+func TestProcessDocument(t *testing.T) {
+	tt := startTUI(t, llmtest.NewServer(t), fakeConfig())
+	tt.srv.Reply(func(r llmtest.Request) string {
+		if strings.HasPrefix(r.System(), "Extract key information") {
+			return `{"key_points": ["Revenue grew 12%"], "entities": ["Acme"], "facts": [], "summary": "Revenue grew."}`
+		}
+		return "Acme revenue grew 12 percent this quarter."
+	})
+	tt.waitFor("Ready - ")
+
+	tt.typeText("pulp://samples/quarterly-report.md")
+	tt.press(tea.KeyEnter)
+	tt.waitFor("What do you want to do with this document?")
+
+	tt.typeText("summarize for execs")
+	tt.press(tea.KeyEnter)
+	tt.waitFor("Acme revenue grew 12 percent this quarter.")
+
+	var extractions, streamed int
+	for _, r := range tt.srv.Requests() {
+		if strings.HasPrefix(r.System(), "Extract key information") {
+			extractions++
+		}
+		if r.Stream {
+			streamed++
+		}
+	}
+	if extractions == 0 || streamed != 1 {
+		t.Errorf("API got %d extraction and %d streamed requests", extractions, streamed)
+	}
+}
+
+func TestChatStreamsReply(t *testing.T) {
+	tt := startTUI(t, llmtest.NewServer(t), fakeConfig())
+	tt.waitFor("Ready - ")
+
+	tt.typeText("hello there")
+	tt.press(tea.KeyEnter)
+	tt.waitFor(llmtest.DefaultReply)
+
+	reqs := tt.srv.Requests()
+	last := reqs[len(reqs)-1]
+	if !last.Stream || last.Messages[len(last.Messages)-1].Content != "hello there" {
+		t.Errorf("API got %+v", last)
+	}
+}
+
+func TestBadAPIKey(t *testing.T) {
+	srv := llmtest.NewServer(t)
+	srv.APIKey = "test-key"
+	cfg := fakeConfig()
+	cfg.APIKey = "wrong-key"
+	tt := startTUI(t, srv, cfg)
+	tt.waitFor("Provider error: invalid API key")
+}
+
+func TestChatShowsAPIError(t *testing.T) {
+	tt := startTUI(t, llmtest.NewServer(t), fakeConfig())
+	tt.srv.FailWith(http.StatusServiceUnavailable)
+	tt.waitFor("Ready - ")
+	tt.typeText("hello there")
+	tt.press(tea.KeyEnter)
+	tt.waitFor("status 503")
+}
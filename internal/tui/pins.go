@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/i18n"
+)
+
+// Pinned items shown in the chat panel before it collapses to a count
+const maxPinnedShown = 3
+
+// handlePinCommand handles /pin and /unpin in chat. Returns false if
+// input isn't a pin command.
+//
+//	/pin          pin the last assistant reply
+//	/pin <n>      pin the n-th bullet (or sentence) of the last reply
+//	/pin <text>   pin a statement (e.g. pasted from a reply)
+//	/unpin <n>    remove pinned item n
+func (a *App) handlePinCommand(input string) bool {
+	cmd, arg, _ := strings.Cut(input, " ")
+	arg = strings.TrimSpace(arg)
+
+	switch strings.ToLower(cmd) {
+	case "/pin":
+		if n, err := strconv.Atoi(arg); err == nil {
+			items := replyItems(a.lastAssistantReply())
+			if n < 1 || n > len(items) {
+				a.state.docError = fmt.Errorf("%s", i18n.T("pins.usage_pick", len(items)))
+				break
+			}
+			arg = items[n-1]
+		}
+		if arg == "" {
+			arg = a.lastAssistantReply()
+		}
+		if arg == "" {
+			a.state.docError = fmt.Errorf("%s", i18n.T("pins.nothing"))
+		} else {
+			a.state.pinned = append(a.state.pinned, arg)
+			a.state.docError = nil
+		}
+	case "/unpin":
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > len(a.state.pinned) {
+			a.state.docError = fmt.Errorf("%s", i18n.T("pins.usage_unpin", len(a.state.pinned)))
+		} else {
+			a.state.pinned = append(a.state.pinned[:n-1], a.state.pinned[n:]...)
+			a.state.docError = nil
+		}
+	default:
+		return false
+	}
+
+	a.state.input.Reset()
+	a.updateContextUsed()
+	return true
+}
+
+func (a *App) lastAssistantReply() string {
+	for i := len(a.state.chatHistory) - 1; i >= 0; i-- {
+		if a.state.chatHistory[i].role == "assistant" {
+			return strings.TrimSpace(a.state.chatHistory[i].content)
+		}
+	}
+	return ""
+}
+
+// List item in a reply: "- text", "* text" or "1. text"
+var listItemPattern = regexp.MustCompile(`^\s*(?:[-*+•]|\d+[.)])\s+(.+)$`)
+
+// replyItems splits a reply into what /pin <n> picks from: its list
+// items, or its sentences if it has no list
+func replyItems(reply string) []string {
+	var items []string
+	for _, line := range strings.Split(reply, "\n") {
+		if m := listItemPattern.FindStringSubmatch(line); m != nil {
+			items = append(items, strings.TrimSpace(m[1]))
+		}
+	}
+	if len(items) > 0 {
+		return items
+	}
+
+	// Sentences end at . ! or ? followed by a space
+	text := strings.Join(strings.Fields(reply), " ")
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if strings.IndexByte(".!?", text[i]) >= 0 && (i+1 == len(text) || text[i+1] == ' ') {
+			items = append(items, strings.TrimSpace(text[start:i+1]))
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		items = append(items, rest)
+	}
+	return items
+}
+
+// pinnedTokens estimates the tokens pinned items add to every prompt
+func (a *App) pinnedTokens() int {
+	total := 0
+	for _, p := range a.state.pinned {
+		total += estimateTokens(p)
+	}
+	return total
+}
+
+// renderPinnedPanel returns the chat's pinned-items panel, one entry
+// per line, or nil with nothing pinned
+func (a *App) renderPinnedPanel(width int) []string {
+	if len(a.state.pinned) == 0 {
+		return nil
+	}
+
+	titleStyle := lipgloss.NewStyle().Foreground(colorPrimary).Bold(true)
+	itemStyle := lipgloss.NewStyle().Foreground(colorMuted)
+
+	lines := []string{titleStyle.Render(i18n.T("pins.title", len(a.state.pinned), a.pinnedTokens()))}
+
+	// Newest pins are the most relevant
+	start := max(0, len(a.state.pinned)-maxPinnedShown)
+	if start > 0 {
+		lines = append(lines, itemStyle.Render("  "+i18n.T("pins.more", start)))
+	}
+	for i := start; i < len(a.state.pinned); i++ {
+		text := strings.Join(strings.Fields(a.state.pinned[i]), " ")
+		lines = append(lines, itemStyle.Render(fmt.Sprintf("  %d. %s", i+1, truncate(text, width-8))))
+	}
+
+	return lines
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sant0-9/pulp/internal/i18n"
+	"github.com/sant0-9/pulp/internal/intent"
+	"github.com/sant0-9/pulp/internal/pipeline"
+	"github.com/sant0-9/pulp/internal/session"
+)
+
+// buildSession snapshots the current document analysis for export
+func (a *App) buildSession() *session.Session {
+	s := &session.Session{
+		Document: a.state.document.Metadata,
+		Markdown: a.state.document.Content,
+		Result:   a.state.result,
+	}
+	if a.state.pipelineResult != nil {
+		s.Aggregated = a.state.pipelineResult.Aggregated
+	}
+	for _, m := range a.state.history {
+		s.Conversation = append(s.Conversation, session.Message{
+			Role:    m.role,
+			Content: m.content,
+		})
+	}
+	return s
+}
+
+// exportSession writes the session bundle next to saved results
+func (a *App) exportSession() tea.Cmd {
+	s := a.buildSession()
+	return func() tea.Msg {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return saveMsg{err: err}
+		}
+		path := filepath.Join(home, "Documents", session.BundleName(s.Document.Title))
+
+		if err := s.ExportFile(path); err != nil {
+			return saveMsg{err: err}
+		}
+		return saveMsg{path: path}
+	}
+}
+
+// publishSession saves the session to the store so `pulp serve` can
+// render it as a read-only transcript page
+func (a *App) publishSession() tea.Cmd {
+	s := a.buildSession()
+	return func() tea.Msg {
+		store, err := session.NewStore()
+		if err != nil {
+			return publishedMsg{err: err}
+		}
+		id, err := store.Save(s)
+		if err != nil {
+			return publishedMsg{err: err}
+		}
+		return publishedMsg{id: id}
+	}
+}
+
+func importSession(path string) tea.Cmd {
+	return func() tea.Msg {
+		s, err := session.ImportFile(path)
+		if err != nil {
+			return documentErrorMsg{err}
+		}
+		return sessionImportedMsg{s}
+	}
+}
+
+// restoreSession loads an imported session so the user can continue it
+func (a *App) restoreSession(s *session.Session) {
+	if s.Kind == session.KindChat {
+		a.restoreChat(s)
+		return
+	}
+
+	doc := s.ToDocument()
+
+	a.state.loadingDoc = false
+	a.state.docError = nil
+	a.state.document = doc
+	a.state.documentPath = doc.Metadata.SourcePath
+	a.state.largeDoc = nil
+	a.state.queued = ""
+	a.state.result = s.Result
+	a.state.notice = ""
+	a.state.docType = intent.Classify(doc.Metadata.Title, doc.Content)
+	a.state.suggestions = intent.Suggestions(a.state.docType, intent.LoadRecent(), 4)
+
+	a.state.history = nil
+	for _, m := range s.Conversation {
+		a.state.history = append(a.state.history, message{
+			role:    m.Role,
+			content: m.Content,
+		})
+	}
+
+	a.state.input.Reset()
+	a.state.input.Focus()
+
+	// Without extraction results the pipeline has to run again
+	if s.Aggregated == nil {
+		a.state.pipelineResult = nil
+		a.state.currentIntent = nil
+		a.state.isFollowUp = false
+		a.state.input.Placeholder = i18n.T("document.prompt")
+		a.view = viewDocument
+		return
+	}
+
+	a.state.pipelineResult = &pipeline.Result{
+		Aggregated: s.Aggregated,
+		Chunks:     pipeline.ChunkDocument(doc.Content, pipeline.DefaultChunkSize),
+		Outline:    pipeline.BuildOutline(doc.Content),
+	}
+	a.state.currentIntent = intent.New(s.LastInstruction())
+	a.state.isFollowUp = s.Result != ""
+	a.view = viewResult
+}
+
+// restoreChat reopens a saved chat transcript in the chat view
+func (a *App) restoreChat(s *session.Session) {
+	a.state.loadingDoc = false
+	a.state.docError = nil
+	a.state.notice = ""
+	a.state.chatHistory = nil
+	for _, m := range s.Conversation {
+		a.state.chatHistory = append(a.state.chatHistory, message{
+			role:    m.Role,
+			content: m.Content,
+		})
+	}
+	a.state.chatSkill = nil
+	a.state.chatScrollOffset = 0
+	a.state.chatSummarizedAt = len(a.state.chatHistory)
+	a.updateContextUsed()
+
+	a.state.input.Reset()
+	a.state.input.Focus()
+	a.state.input.Placeholder = i18n.T("recall.placeholder")
+	a.view = viewChat
+}
@@ -6,10 +6,14 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/sant0-9/pulp/internal/config"
 	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/i18n"
 	"github.com/sant0-9/pulp/internal/intent"
 	"github.com/sant0-9/pulp/internal/llm"
 	"github.com/sant0-9/pulp/internal/pipeline"
+	"github.com/sant0-9/pulp/internal/session"
 	"github.com/sant0-9/pulp/internal/skill"
+	"github.com/sant0-9/pulp/internal/verify"
+	"github.com/sant0-9/pulp/internal/writer"
 )
 
 type state struct {
@@ -27,6 +31,11 @@ type state struct {
 	documentPath string
 	loadingDoc   bool
 	docError     error
+	docType      intent.DocType
+	suggestions  []string      // One-tap instructions for the document view
+	largeDoc     *largeDocInfo // Set until a huge document is confirmed
+	hierarchical bool          // Process in hierarchical mode
+	queued       string        // Instruction to run once the document loads (/skill <file>)
 
 	// Processing
 	processing   bool
@@ -36,6 +45,32 @@ type state struct {
 	// Result
 	result    string
 	streaming bool
+	notice    string // One-line feedback for save/export actions
+
+	// Saved results for the document; versionIndex is the one on
+	// screen (-1 when the result isn't a saved version)
+	versions     []session.Version
+	versionIndex int
+	revisionBase string // Earlier version a follow-up revises
+
+	// Check of the result against the document; stale once the result
+	// differs from verifiedResult
+	verification   *verify.Report
+	verifiedResult string
+
+	// First-run tour
+	tourActive bool
+	tourStep   int
+
+	// Dry run: plan prompts instead of calling the LLM
+	dryRun            bool
+	resultIsPlan      bool
+	dryRunInstruction string
+	dryRunFollowUp    bool // dryRunInstruction is a follow-up on the result
+
+	// Redacted copy of the document from /anonymize, shown as the result
+	anonymizing bool
+	anonymized  *converter.Document
 
 	// Input
 	input textinput.Model
@@ -59,6 +94,11 @@ type state struct {
 	pipelineResult   *pipeline.Result
 	processingError  error
 
+	// Runs in progress, canceled with Esc
+	activePipeline *pipeline.Pipeline
+	activeWriter   *writer.Writer
+	chatCancel     llm.Canceler // Chat replies and summaries
+
 	// Skills
 	skillIndex       *skill.SkillIndex
 	generatingSkill  bool
@@ -71,8 +111,9 @@ type state struct {
 	cmdPaletteItems    []cmdItem
 
 	// Settings sub-views
-	settingsMode     string // "", "provider", "model", "apikey"
+	settingsMode     string // "", "provider", "model", "apikey", "generation"
 	settingsSelected int
+	genDraft         config.GenerationSettings // Edited on the generation page, saved on Enter
 	modelInput       textinput.Model
 
 	// Chat mode (no document)
@@ -80,6 +121,13 @@ type state struct {
 	chatResult    string
 	chatStreaming bool
 	chatSkill     *skill.Skill // Active skill for chat mode
+	pinned        []string     // Pinned facts included in every chat prompt
+
+	// Chat summaries: offered when leaving a long chat, recalled with /recall
+	chatExitPrompt   bool
+	chatSummarizing  bool
+	chatSummarizedAt int // History length at the last summary or declined offer
+	chatSummaries    []session.Entry
 
 	// Streaming stats
 	streamStart    time.Time
@@ -98,6 +146,14 @@ type state struct {
 	chatAutoScroll   bool // Auto-scroll to bottom on new content
 }
 
+// largeDocInfo describes a document over the size thresholds
+type largeDocInfo struct {
+	words             int
+	pages             int
+	calls             int
+	hierarchicalCalls int
+}
+
 type cmdItem struct {
 	cmd  string
 	desc string
@@ -110,18 +166,18 @@ type message struct {
 
 func newState() *state {
 	input := textinput.New()
-	input.Placeholder = "/help for commands, or drop a file..."
+	input.Placeholder = i18n.T("welcome.placeholder")
 	input.CharLimit = 500
 	input.Width = 60
 
 	apiKey := textinput.New()
-	apiKey.Placeholder = "Paste your API key here..."
+	apiKey.Placeholder = i18n.T("setup.key_placeholder")
 	apiKey.EchoMode = textinput.EchoPassword
 	apiKey.CharLimit = 200
 	apiKey.Width = 50
 
 	modelInput := textinput.New()
-	modelInput.Placeholder = "Enter model name..."
+	modelInput.Placeholder = i18n.T("settings.model_placeholder")
 	modelInput.CharLimit = 100
 	modelInput.Width = 40
 
@@ -133,5 +189,7 @@ func newState() *state {
 		apiKeyInput: apiKey,
 		modelInput:  modelInput,
 		skillIndex:  skillIdx,
+
+		versionIndex: -1,
 	}
 }
@@ -19,6 +19,7 @@ var (
 	colorSecondary = lipgloss.Color("#06B6D4")
 	colorSuccess   = lipgloss.Color("#10B981")
 	colorError     = lipgloss.Color("#EF4444")
+	colorWarning   = lipgloss.Color("#F59E0B")
 	colorMuted     = lipgloss.Color("#6B7280")
 	colorWhite     = lipgloss.Color("#F9FAFB")
 	colorDark      = lipgloss.Color("#1F2937")
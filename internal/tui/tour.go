@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/sant0-9/pulp/internal/i18n"
+	"github.com/sant0-9/pulp/internal/tour"
+)
+
+// Tour steps, indexes into tour.Steps
+const (
+	tourStepLoad = iota
+	tourStepSummarize
+	tourStepSkill
+	tourStepChat
+)
+
+// startTour begins the first-run tour from the welcome screen
+func (a *App) startTour() {
+	a.state.tourActive = true
+	a.state.tourStep = tourStepLoad
+}
+
+// advanceTour moves to the next step if the tour is on step.
+// Steps advance on what the user actually did, so loading their own
+// file counts just as well as loading the sample.
+func (a *App) advanceTour(step int) {
+	if !a.state.tourActive || a.state.tourStep != step {
+		return
+	}
+	a.state.tourStep++
+	if a.state.tourStep >= len(tour.Steps) {
+		a.state.tourActive = false
+	}
+}
+
+// renderTourBanner renders the current step as a one-line hint
+func (a *App) renderTourBanner() string {
+	step := tour.Steps[a.state.tourStep]
+	title := i18n.T("tour." + step + ".title")
+
+	label := lipgloss.NewStyle().
+		Foreground(colorPrimary).
+		Bold(true).
+		Render(i18n.T("tour.label", a.state.tourStep+1, len(tour.Steps), title))
+	hint := lipgloss.NewStyle().
+		Foreground(colorSecondary).
+		Render(i18n.T("tour." + step + ".hint"))
+	skip := styleStatusBar.Render(i18n.T("tour.end_keys"))
+
+	line := lipgloss.JoinHorizontal(lipgloss.Top, label, "  ", hint, "  ", skip)
+	return lipgloss.PlaceHorizontal(a.width, lipgloss.Center, line)
+}
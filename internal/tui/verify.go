@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/i18n"
+	"github.com/sant0-9/pulp/internal/verify"
+)
+
+// toggleVerify checks the result on screen against the document's
+// chunks, or hides the check
+func (a *App) toggleVerify() {
+	if a.currentVerification() != nil {
+		a.state.verification = nil
+		return
+	}
+
+	var sources []string
+	if a.state.document != nil {
+		sources = append(sources, a.state.document.Metadata.Title)
+	}
+	if a.state.pipelineResult != nil && len(a.state.pipelineResult.Chunks) > 0 {
+		for _, c := range a.state.pipelineResult.Chunks {
+			text := c.Content
+			for _, n := range c.Footnotes {
+				text += "\n" + n.Text
+			}
+			sources = append(sources, text)
+		}
+	} else if a.state.document != nil {
+		// Restored sessions have no chunks
+		sources = append(sources, a.state.document.Content)
+	}
+
+	a.state.verification = verify.Check(a.state.result, sources)
+	a.state.verifiedResult = a.state.result
+}
+
+// currentVerification returns the check for the result on screen, nil
+// if there is none or the result changed since
+func (a *App) currentVerification() *verify.Report {
+	if a.state.verification == nil || a.state.verifiedResult != a.state.result {
+		return nil
+	}
+	return a.state.verification
+}
+
+// highlightUnverified marks numbers and names from the report that
+// aren't in the document (red) or only match rounded (yellow)
+func highlightUnverified(text string, report *verify.Report) string {
+	styles := make(map[string]lipgloss.Style)
+	var alts []string
+	for _, it := range report.Items {
+		var style lipgloss.Style
+		switch it.Status {
+		case verify.Missing:
+			style = lipgloss.NewStyle().Foreground(colorError).Underline(true)
+		case verify.Rounded:
+			style = lipgloss.NewStyle().Foreground(colorWarning)
+		default:
+			continue
+		}
+		styles[it.Text] = style
+		alts = append(alts, it.Text)
+	}
+	if len(alts) == 0 {
+		return text
+	}
+
+	// Longest first; word boundaries where the item starts or ends with
+	// a word character, so "5" doesn't match inside "2025"
+	sort.Slice(alts, func(i, j int) bool { return len(alts[i]) > len(alts[j]) })
+	for i, alt := range alts {
+		p := regexp.QuoteMeta(alt)
+		if isWordChar(alt[0]) {
+			p = `\b` + p
+		}
+		if isWordChar(alt[len(alt)-1]) {
+			p += `\b`
+		}
+		alts[i] = p
+	}
+	re := regexp.MustCompile(strings.Join(alts, "|"))
+	return re.ReplaceAllStringFunc(text, func(m string) string {
+		return styles[m].Render(m)
+	})
+}
+
+func isWordChar(b byte) bool {
+	return b == '_' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// verifySummary is the line under a verified result
+func verifySummary(report *verify.Report) string {
+	missing := report.Missing()
+	if len(missing) == 0 {
+		return i18n.T("verify.all_found", len(report.Items))
+	}
+	var names []string
+	for _, it := range missing {
+		names = append(names, it.Text)
+	}
+	return i18n.T("verify.summary", len(report.Items), report.Count(verify.Rounded), len(missing), strings.Join(names, ", "))
+}
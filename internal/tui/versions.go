@@ -0,0 +1,65 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sant0-9/pulp/internal/session"
+)
+
+// loadVersions reads the saved results for the current document
+func (a *App) loadVersions() tea.Cmd {
+	if a.state.document == nil {
+		return nil
+	}
+	key := session.DocumentKey(a.state.document.Content)
+	return func() tea.Msg {
+		store, err := session.NewStore()
+		if err != nil {
+			return versionsMsg{key: key}
+		}
+		versions, _ := store.Versions(key)
+		return versionsMsg{key: key, versions: versions}
+	}
+}
+
+// saveVersion stores the result that just finished streaming
+func (a *App) saveVersion() tea.Cmd {
+	if a.state.document == nil || a.state.result == "" || a.state.resultIsPlan {
+		return nil
+	}
+	key := session.DocumentKey(a.state.document.Content)
+	v := session.Version{
+		Result:   a.state.result,
+		FollowUp: a.state.isFollowUp,
+	}
+	if a.state.currentIntent != nil {
+		v.Instruction = a.state.currentIntent.RawPrompt
+		v.Skill = a.state.currentIntent.SkillName()
+	}
+	return func() tea.Msg {
+		store, err := session.NewStore()
+		if err != nil {
+			return versionsMsg{key: key}
+		}
+		store.SaveVersion(key, v)
+		versions, _ := store.Versions(key)
+		return versionsMsg{key: key, versions: versions, latest: true}
+	}
+}
+
+// showVersion switches the result view to version i (0-based)
+func (a *App) showVersion(i int) {
+	if i < 0 || i >= len(a.state.versions) {
+		return
+	}
+	a.state.versionIndex = i
+	a.state.result = a.state.versions[i].Result
+	a.state.notice = ""
+}
+
+// viewingVersion returns the version shown in the result view, or nil
+func (a *App) viewingVersion() *session.Version {
+	if a.state.versionIndex < 0 || a.state.versionIndex >= len(a.state.versions) {
+		return nil
+	}
+	return &a.state.versions[a.state.versionIndex]
+}
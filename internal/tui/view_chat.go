@@ -6,24 +6,26 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
-// Loading messages shown during connecting/thinking phase
+// Loading messages shown during connecting/thinking phase (catalog keys)
 var loadingMessages = []string{
-	"Thinking...",
-	"Processing...",
-	"Contemplating...",
-	"Pondering...",
-	"Analyzing...",
-	"Brewing thoughts...",
-	"Gathering wisdom...",
-	"Connecting neurons...",
+	"chat.loading.thinking",
+	"chat.loading.processing",
+	"chat.loading.contemplating",
+	"chat.loading.pondering",
+	"chat.loading.analyzing",
+	"chat.loading.brewing",
+	"chat.loading.gathering",
+	"chat.loading.neurons",
 }
 
 // Spinner frames for animation
 var spinnerFrames = []string{".", "o", "O", "o"}
 
-func (a *App) renderChat() string {
+func (a *App) renderChat(height int) string {
 	// Use full width with padding
 	contentWidth := a.width - 4
 	if contentWidth < 40 {
@@ -34,11 +36,12 @@ func (a *App) renderChat() string {
 	// Fixed footer height: input line + status line = 2
 	footerHeight := 2
 
-	// Header is minimal: just 1 line for context info
-	headerHeight := 1
+	// Header is minimal: 1 line for context info, plus pinned items
+	pinnedLines := a.renderPinnedPanel(contentWidth)
+	headerHeight := 1 + len(pinnedLines)
 
 	// Available height for messages
-	availableHeight := a.height - headerHeight - footerHeight - 1 // -1 for spacing
+	availableHeight := height - headerHeight - footerHeight - 1 // -1 for spacing
 	if availableHeight < 3 {
 		availableHeight = 3
 	}
@@ -72,7 +75,7 @@ func (a *App) renderChat() string {
 	if a.state.docError != nil {
 		errLine := lipgloss.NewStyle().
 			Foreground(colorError).
-			Render("Error: " + a.state.docError.Error())
+			Render(i18n.T("common.error", a.state.docError.Error()))
 		messageLines = append(messageLines, indent+errLine, "")
 	}
 
@@ -120,7 +123,7 @@ func (a *App) renderChat() string {
 			msgIdx := int(elapsed*2) % len(loadingMessages)
 			loadingText := lipgloss.NewStyle().
 				Foreground(colorPrimary).
-				Render(fmt.Sprintf("  %s %s", spinner, loadingMessages[msgIdx]))
+				Render(fmt.Sprintf("  %s %s", spinner, i18n.T(loadingMessages[msgIdx])))
 			messageLines = append(messageLines, indent+loadingText)
 		} else {
 			// Show streaming response
@@ -187,14 +190,23 @@ func (a *App) renderChat() string {
 		Bold(true).
 		Render("> ")
 
-	if a.state.chatStreaming {
+	if a.state.chatStreaming || a.state.chatSummarizing {
 		// Show streaming indicator instead of input
+		label := i18n.T("chat.streaming")
+		if a.state.chatSummarizing {
+			label = i18n.T("chat.summarizing")
+		}
 		spinner := spinnerFrames[a.state.spinnerFrame%len(spinnerFrames)]
 		streamingText := lipgloss.NewStyle().
 			Foreground(colorMuted).
 			Italic(true).
-			Render(fmt.Sprintf("%s Streaming...", spinner))
+			Render(spinner + " " + label)
 		footerLines = append(footerLines, indent+prompt+streamingText)
+	} else if a.state.chatExitPrompt {
+		offer := lipgloss.NewStyle().
+			Foreground(colorSecondary).
+			Render(i18n.T("chat.summary_offer", len(a.state.chatHistory)))
+		footerLines = append(footerLines, indent+prompt+offer)
 	} else {
 		inputStyle := lipgloss.NewStyle().
 			Foreground(colorWhite)
@@ -206,12 +218,14 @@ func (a *App) renderChat() string {
 	var statusParts []string
 	if a.state.chatStreaming {
 		statusParts = append(statusParts, a.buildStreamStatus())
-		statusParts = append(statusParts, "[Esc] Cancel")
+		statusParts = append(statusParts, i18n.T("chat.cancel_keys"))
+	} else if a.state.chatExitPrompt {
+		statusParts = append(statusParts, i18n.T("chat.summary_keys"))
 	} else {
 		if a.state.chatScrollOffset > 0 {
-			statusParts = append(statusParts, fmt.Sprintf("scroll: %d", a.state.chatScrollOffset))
+			statusParts = append(statusParts, i18n.T("chat.scroll", a.state.chatScrollOffset))
 		}
-		statusParts = append(statusParts, "[Ctrl+U/D] Scroll  [Esc] Back")
+		statusParts = append(statusParts, i18n.T("chat.keys"))
 	}
 
 	statusLine := lipgloss.NewStyle().
@@ -244,6 +258,10 @@ func (a *App) renderChat() string {
 	// Header
 	output.WriteString(indent + header)
 	output.WriteString("\n")
+	for _, line := range pinnedLines {
+		output.WriteString(indent + line)
+		output.WriteString("\n")
+	}
 
 	// Messages (fills available space)
 	output.WriteString(messageArea.String())
@@ -255,7 +273,9 @@ func (a *App) renderChat() string {
 	return output.String()
 }
 
-// wrapText wraps text to fit within maxWidth, preserving words
+// wrapText wraps text to fit within maxWidth, preserving words. Lines in
+// fenced code blocks and markdown tables are never wrapped, since that
+// mangles their layout; lines too wide are cut with a marker instead.
 func wrapText(text string, maxWidth int) string {
 	if maxWidth <= 0 {
 		maxWidth = 60
@@ -263,12 +283,22 @@ func wrapText(text string, maxWidth int) string {
 
 	var result strings.Builder
 	lines := strings.Split(text, "\n")
+	inCode := false
 
 	for lineIdx, line := range lines {
 		if lineIdx > 0 {
 			result.WriteString("\n")
 		}
 
+		fence := isCodeFence(line)
+		if fence {
+			inCode = !inCode
+		}
+		if fence || inCode || isTableLine(line) {
+			result.WriteString(cutLine(line, maxWidth))
+			continue
+		}
+
 		if len(line) <= maxWidth {
 			result.WriteString(line)
 			continue
@@ -295,6 +325,30 @@ func wrapText(text string, maxWidth int) string {
 	return result.String()
 }
 
+// Marks a code or table line cut at the edge of the chat
+const cutMarker = "›"
+
+// cutLine truncates a line to maxWidth columns, ending it with cutMarker
+func cutLine(line string, maxWidth int) string {
+	line = strings.ReplaceAll(line, "\t", "    ")
+	if ansi.StringWidth(line) <= maxWidth {
+		return line
+	}
+	return ansi.Truncate(line, maxWidth, cutMarker)
+}
+
+// isCodeFence reports whether line opens or closes a fenced code block
+func isCodeFence(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// isTableLine reports whether line is a markdown table row
+func isTableLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return len(trimmed) > 1 && strings.HasPrefix(trimmed, "|")
+}
+
 // buildStreamStatus builds the dynamic status line during streaming
 func (a *App) buildStreamStatus() string {
 	var parts []string
@@ -305,20 +359,20 @@ func (a *App) buildStreamStatus() string {
 	switch a.state.streamPhase {
 	case "connecting":
 		msgIdx := int(elapsed*2) % len(loadingMessages)
-		parts = append(parts, fmt.Sprintf("%s %s", spinner, loadingMessages[msgIdx]))
+		parts = append(parts, fmt.Sprintf("%s %s", spinner, i18n.T(loadingMessages[msgIdx])))
 	case "streaming":
 		if elapsed > 0 && a.state.streamTokens > 0 {
 			tokPerSec := float64(a.state.streamTokens) / elapsed
 			parts = append(parts, fmt.Sprintf("%s %.0f tok/s", spinner, tokPerSec))
 		} else {
-			parts = append(parts, fmt.Sprintf("%s Streaming...", spinner))
+			parts = append(parts, spinner+" "+i18n.T("chat.streaming"))
 		}
 	case "complete":
 		if elapsed > 0 && a.state.streamTokens > 0 {
 			tokPerSec := float64(a.state.streamTokens) / elapsed
-			parts = append(parts, fmt.Sprintf("%d tokens (%.0f tok/s)", a.state.streamTokens, tokPerSec))
+			parts = append(parts, i18n.T("chat.tokens_rate", a.state.streamTokens, tokPerSec))
 		} else {
-			parts = append(parts, fmt.Sprintf("%d tokens", a.state.streamTokens))
+			parts = append(parts, i18n.T("chat.tokens", a.state.streamTokens))
 		}
 	default:
 		parts = append(parts, spinner)
@@ -394,7 +448,7 @@ func (a *App) getModelDisplayName() string {
 	}
 
 	if provider != "" && !strings.Contains(strings.ToLower(displayModel), strings.ToLower(provider)) {
-		return fmt.Sprintf("%s via %s", displayModel, provider)
+		return i18n.T("chat.model_via", displayModel, provider)
 	}
 	return displayModel
 }
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapTextKeepsCodeAndTables(t *testing.T) {
+	text := "Here is a long sentence that should wrap across lines.\n" +
+		"```go\n" +
+		"fmt.Println(\"a line of code far wider than the chat\")\n" +
+		"```\n" +
+		"| Name | Description that is too wide |\n" +
+		"|------|------------------------------|"
+
+	lines := strings.Split(wrapText(text, 20), "\n")
+
+	want := []string{
+		"Here is a long",
+		"sentence that should",
+		"wrap across lines.",
+		"```go",
+		"fmt.Println(\"a line›",
+		"```",
+		"| Name | Descriptio›",
+		"|------|-----------›",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), strings.Join(lines, "\n"))
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
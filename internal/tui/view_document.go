@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
 func min(a, b int) int {
@@ -14,9 +15,9 @@ func min(a, b int) int {
 	return b
 }
 
-func (a *App) renderDocument() string {
+func (a *App) renderDocument(height int) string {
 	if a.state.document == nil {
-		return a.renderWelcome()
+		return a.renderWelcome(height)
 	}
 
 	var b strings.Builder
@@ -32,11 +33,11 @@ func (a *App) renderDocument() string {
 	// Metadata line
 	var metaParts []string
 	if meta.PageCount != nil {
-		metaParts = append(metaParts, fmt.Sprintf("%d pages", *meta.PageCount))
+		metaParts = append(metaParts, i18n.T("document.pages", *meta.PageCount))
 	}
 	metaParts = append(metaParts, strings.ToUpper(meta.SourceFormat))
 	metaParts = append(metaParts, meta.FileSizeHuman())
-	metaParts = append(metaParts, fmt.Sprintf("~%d words", meta.WordCount))
+	metaParts = append(metaParts, i18n.T("document.words", meta.WordCount))
 
 	metaLine := styleSubtitle.Render(strings.Join(metaParts, "  |  "))
 
@@ -55,7 +56,7 @@ func (a *App) renderDocument() string {
 	b.WriteString("\n\n")
 
 	// Preview
-	previewLabel := styleSubtitle.Render("Preview:")
+	previewLabel := styleSubtitle.Render(i18n.T("document.preview"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, previewLabel))
 	b.WriteString("\n")
 
@@ -66,10 +67,20 @@ func (a *App) renderDocument() string {
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, previewBox))
 	b.WriteString("\n\n")
 
+	// Huge documents: confirm before kicking off extraction
+	if a.state.largeDoc != nil {
+		b.WriteString(a.renderLargeDocWarning())
+		b.WriteString("\n\n")
+
+		statusBar := styleStatusBar.Render(i18n.T("document.large_keys"))
+		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, statusBar))
+		return a.centerVertically(b.String(), height)
+	}
+
 	// Instruction prompt
 	promptLabel := lipgloss.NewStyle().
 		Foreground(colorWhite).
-		Render("What do you want to do with this document?")
+		Render(i18n.T("document.prompt"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, promptLabel))
 	b.WriteString("\n\n")
 
@@ -83,9 +94,19 @@ func (a *App) renderDocument() string {
 
 	// Show parsing status or parsed intent
 	if a.state.parsingIntent {
-		parsingLabel := styleSubtitle.Render("Parsing instruction...")
+		parsingLabel := styleSubtitle.Render(i18n.T("document.parsing"))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, parsingLabel))
 		b.WriteString("\n\n")
+	} else if a.state.anonymizing {
+		label := styleSubtitle.Render(i18n.T("document.anonymizing"))
+		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, label))
+		b.WriteString("\n\n")
+	} else if a.state.docError != nil {
+		errLine := lipgloss.NewStyle().
+			Foreground(colorError).
+			Render(i18n.T("common.error", truncate(a.state.docError.Error(), 60)))
+		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, errLine))
+		b.WriteString("\n\n")
 	} else if a.state.currentIntent != nil {
 		intentBox := styleBox.Copy().
 			Width(min(70, a.width-4)).
@@ -93,11 +114,62 @@ func (a *App) renderDocument() string {
 			Render(fmt.Sprintf("> %s", a.state.currentIntent.RawPrompt))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, intentBox))
 		b.WriteString("\n\n")
+	} else if len(a.state.suggestions) > 0 {
+		b.WriteString(a.renderSuggestions())
+		b.WriteString("\n\n")
 	}
 
 	// Status bar
-	statusBar := styleStatusBar.Render("[Enter] Submit  [n] New document  [Esc] Quit")
+	keys := i18n.T("document.keys")
+	if a.state.dryRun {
+		keys = i18n.T("document.dry_run") + "  " + keys
+	}
+	statusBar := styleStatusBar.Render(keys)
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, statusBar))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
+}
+
+// renderSuggestions lists one-tap instructions for the document type
+func (a *App) renderSuggestions() string {
+	keyStyle := lipgloss.NewStyle().Foreground(colorPrimary).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(colorWhite)
+
+	var lines []string
+	label := styleSubtitle.Render(i18n.T("document.suggestions", i18n.T("doctype."+string(a.state.docType))))
+	lines = append(lines, label)
+	for i, s := range a.state.suggestions {
+		lines = append(lines, fmt.Sprintf("%s %s", keyStyle.Render(fmt.Sprintf("[Alt+%d]", i+1)), textStyle.Render(s)))
+	}
+
+	block := lipgloss.NewStyle().
+		Width(min(70, a.width-4)).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.PlaceHorizontal(a.width, lipgloss.Center, block)
+}
+
+// renderLargeDocWarning explains the cost of processing a huge document
+func (a *App) renderLargeDocWarning() string {
+	info := a.state.largeDoc
+
+	size := i18n.T("document.words", info.words)
+	if info.pages > 0 {
+		size = i18n.T("document.pages", info.pages) + ", " + size
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().Foreground(colorError).Bold(true).Render(i18n.T("large.title")),
+		"",
+		i18n.T("large.size", size),
+		i18n.T("large.calls", info.calls),
+		"",
+		i18n.T("large.recommend", info.hierarchicalCalls),
+		i18n.T("large.recommend_detail"),
+	}
+
+	box := styleBox.Copy().
+		Width(min(70, a.width-4)).
+		BorderForeground(colorError).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.PlaceHorizontal(a.width, lipgloss.Center, box)
 }
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
 func (a *App) renderError() string {
@@ -13,12 +14,12 @@ func (a *App) renderError() string {
 	title := lipgloss.NewStyle().
 		Foreground(colorError).
 		Bold(true).
-		Render("Something went wrong")
+		Render(i18n.T("error.title"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
 	// Error message
-	errMsg := "Unknown error"
+	errMsg := i18n.T("error.unknown")
 	if a.state.processingError != nil {
 		errMsg = a.state.processingError.Error()
 	} else if a.state.providerError != nil {
@@ -39,37 +40,37 @@ func (a *App) renderError() string {
 	errLower := strings.ToLower(errMsg)
 
 	if strings.Contains(errLower, "api key") || strings.Contains(errLower, "401") || strings.Contains(errLower, "unauthorized") {
-		suggestions = append(suggestions, "Check your API key in ~/.config/pulp/config.yaml")
-		suggestions = append(suggestions, "Or press [s] to open settings")
+		suggestions = append(suggestions, i18n.T("error.api_key"))
+		suggestions = append(suggestions, i18n.T("error.api_key_settings"))
 	} else if strings.Contains(errLower, "connection") || strings.Contains(errLower, "connect") || strings.Contains(errLower, "timeout") {
-		suggestions = append(suggestions, "Check your internet connection")
-		suggestions = append(suggestions, "Or try using Ollama for offline mode")
+		suggestions = append(suggestions, i18n.T("error.connection"))
+		suggestions = append(suggestions, i18n.T("error.connection_offline"))
 	} else if strings.Contains(errLower, "ollama") {
-		suggestions = append(suggestions, "Make sure Ollama is running: ollama serve")
-		suggestions = append(suggestions, "Or switch to a cloud provider in settings")
+		suggestions = append(suggestions, i18n.T("error.ollama"))
+		suggestions = append(suggestions, i18n.T("error.ollama_cloud"))
 	} else if strings.Contains(errLower, "not found") || strings.Contains(errLower, "no such file") {
-		suggestions = append(suggestions, "Check the file path is correct")
-		suggestions = append(suggestions, "Make sure the file exists and is readable")
+		suggestions = append(suggestions, i18n.T("error.not_found"))
+		suggestions = append(suggestions, i18n.T("error.not_found_readable"))
 	} else if strings.Contains(errLower, "docling") || strings.Contains(errLower, "python") {
-		suggestions = append(suggestions, "Make sure Python and Docling are installed:")
+		suggestions = append(suggestions, i18n.T("error.docling"))
 		suggestions = append(suggestions, "  pip install docling")
 	} else if strings.Contains(errLower, "rate limit") || strings.Contains(errLower, "429") {
-		suggestions = append(suggestions, "You've hit the API rate limit")
-		suggestions = append(suggestions, "Wait a moment and try again")
+		suggestions = append(suggestions, i18n.T("error.rate_limit"))
+		suggestions = append(suggestions, i18n.T("error.rate_limit_wait"))
 	}
 
 	if len(suggestions) > 0 {
 		suggBox := styleBox.Copy().
 			Width(min(60, a.width-4)).
 			BorderForeground(colorMuted).
-			Render("Suggestions:\n" + strings.Join(suggestions, "\n"))
+			Render(i18n.T("error.suggestions") + "\n" + strings.Join(suggestions, "\n"))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, suggBox))
 		b.WriteString("\n\n")
 	}
 
 	// Actions
-	status := styleStatusBar.Render("[r] Retry  [s] Settings  [n] New  [Esc] Back")
+	status := styleStatusBar.Render(i18n.T("error.keys"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, status))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), a.height)
 }
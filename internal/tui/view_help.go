@@ -4,29 +4,39 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
-func (a *App) renderHelp() string {
+func (a *App) renderHelp(height int) string {
 	var b strings.Builder
 
 	// Title
 	title := lipgloss.NewStyle().
 		Foreground(colorPrimary).
 		Bold(true).
-		Render("Help")
+		Render(i18n.T("help.title"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
 	// Commands
 	commands := []string{
-		"  /help, /h        Show this help",
-		"  /settings, /s    Open settings",
-		"  /skills          List installed skills",
-		"  /new-skill       Create a new skill with AI",
-		"  /<skill-name>    Use a specific skill",
-		"  /quit, /q        Quit pulp",
+		"  /help, /h        " + i18n.T("help.help"),
+		"  /settings, /s    " + i18n.T("help.settings"),
+		"  /skills          " + i18n.T("help.skills"),
+		"  /new-skill       " + i18n.T("help.new_skill"),
+		"  /import <file>   " + i18n.T("help.import"),
+		"  /dry-run         " + i18n.T("help.dry_run"),
+		"  /tour            " + i18n.T("help.tour"),
+		"  /pin [n|text]    " + i18n.T("help.pin"),
+		"  /unpin <n>       " + i18n.T("help.unpin"),
+		"  /recall [n]      " + i18n.T("help.recall"),
+		"  /send [output]   " + i18n.T("help.send"),
+		"  /anonymize       " + i18n.T("help.anonymize"),
+		"  /<skill-name>    " + i18n.T("help.skill"),
+		"  /quit, /q        " + i18n.T("help.quit"),
 		"",
-		"  Or drop a file path to process a document",
+		"  " + i18n.T("help.drop_file"),
+		"  " + i18n.T("help.sample"),
 	}
 
 	commandsBox := styleBox.Copy().
@@ -37,12 +47,13 @@ func (a *App) renderHelp() string {
 
 	// Keyboard shortcuts
 	shortcuts := []string{
-		"  Esc            Go back / Quit",
-		"  Enter          Submit input",
-		"  s              Quick settings (from welcome)",
+		"  Esc            " + i18n.T("help.key_esc"),
+		"  Enter          " + i18n.T("help.key_enter"),
+		"  s              " + i18n.T("help.key_s"),
+		"  v              " + i18n.T("help.key_v"),
 	}
 
-	shortcutsTitle := styleSubtitle.Render("Keyboard Shortcuts")
+	shortcutsTitle := styleSubtitle.Render(i18n.T("help.shortcuts"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, shortcutsTitle))
 	b.WriteString("\n\n")
 
@@ -53,8 +64,8 @@ func (a *App) renderHelp() string {
 	b.WriteString("\n\n")
 
 	// Instructions
-	instructions := styleStatusBar.Render("[Esc] Back")
+	instructions := styleStatusBar.Render(i18n.T("common.back_keys"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, instructions))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
@@ -4,18 +4,19 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
-func (a *App) renderNewSkill() string {
+func (a *App) renderNewSkill(height int) string {
 	var b strings.Builder
 
 	// Header
-	title := styleLogo.Render("Create New Skill")
+	title := styleLogo.Render(i18n.T("newskill.title"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
 	// Description
-	desc := styleSubtitle.Render("Describe what the skill should do")
+	desc := styleSubtitle.Render(i18n.T("newskill.subtitle"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, desc))
 	b.WriteString("\n\n")
 
@@ -24,13 +25,13 @@ func (a *App) renderNewSkill() string {
 		generating := styleBox.Copy().
 			Width(min(70, a.width-4)).
 			BorderForeground(colorSecondary).
-			Render("Generating skill...")
+			Render(i18n.T("newskill.generating"))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, generating))
 	} else if a.state.newSkillError != nil {
 		errorBox := styleBox.Copy().
 			Width(min(70, a.width-4)).
 			BorderForeground(colorError).
-			Render("Error: " + a.state.newSkillError.Error())
+			Render(i18n.T("common.error", a.state.newSkillError.Error()))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, errorBox))
 		b.WriteString("\n\n")
 
@@ -52,7 +53,7 @@ func (a *App) renderNewSkill() string {
 
 	// Examples
 	if !a.state.generatingSkill {
-		examples := styleSubtitle.Render("Examples: \"extract action items\" or \"summarize for executives\"")
+		examples := styleSubtitle.Render(i18n.T("newskill.examples"))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, examples))
 		b.WriteString("\n\n")
 	}
@@ -60,11 +61,11 @@ func (a *App) renderNewSkill() string {
 	// Status bar
 	var status string
 	if a.state.generatingSkill {
-		status = styleStatusBar.Render("Generating...")
+		status = styleStatusBar.Render(i18n.T("newskill.generating_status"))
 	} else {
-		status = styleStatusBar.Render("[Enter] Create  [Esc] Cancel")
+		status = styleStatusBar.Render(i18n.T("newskill.keys"))
 	}
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, status))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
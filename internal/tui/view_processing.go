@@ -5,16 +5,17 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
-func (a *App) renderProcessing() string {
+func (a *App) renderProcessing(height int) string {
 	var b strings.Builder
 
 	// Title
 	title := lipgloss.NewStyle().
 		Foreground(colorPrimary).
 		Bold(true).
-		Render("Processing")
+		Render(i18n.T("processing.title"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
@@ -33,7 +34,7 @@ func (a *App) renderProcessing() string {
 	}
 
 	// Progress stages
-	stages := []string{"Chunking", "Extracting", "Aggregating"}
+	stages := []string{i18n.T("processing.chunking"), i18n.T("processing.extracting"), i18n.T("processing.aggregating")}
 	currentStage := 0
 	if a.state.pipelineProgress != nil {
 		currentStage = a.state.pipelineProgress.StageIndex
@@ -88,6 +89,8 @@ func (a *App) renderProcessing() string {
 		msg := styleSubtitle.Render(truncate(a.state.pipelineProgress.Message, 60))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, msg))
 	}
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, styleStatusBar.Render(i18n.T("processing.keys"))))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
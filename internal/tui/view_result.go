@@ -4,23 +4,53 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
-func (a *App) renderResult() string {
+func (a *App) renderResult(height int) string {
 	var b strings.Builder
 
 	// Document info (small)
-	if a.state.document != nil {
+	if a.state.anonymized != nil {
+		docInfo := styleSubtitle.Render(truncate(a.state.anonymized.Metadata.Title, 60))
+		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, docInfo))
+		b.WriteString("\n\n")
+	} else if a.state.document != nil {
 		docInfo := styleSubtitle.Render(truncate(a.state.document.Metadata.Title, 60))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, docInfo))
 		b.WriteString("\n")
 	}
 
-	// Show what was asked (user message)
-	if a.state.currentIntent != nil {
+	// Show what was asked (user message), or what produced the version
+	// being browsed
+	version := a.viewingVersion()
+	if a.state.streaming || a.state.anonymized != nil {
+		version = nil
+	}
+	if version != nil {
+		asked := styleSubtitle.Render("> " + truncate(version.Instruction, 55))
+		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, asked))
+		b.WriteString("\n")
+	} else if a.state.currentIntent != nil && a.state.anonymized == nil {
 		asked := styleSubtitle.Render("> " + truncate(a.state.currentIntent.RawPrompt, 55))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, asked))
-		b.WriteString("\n\n")
+		b.WriteString("\n")
+	}
+
+	// Version switcher
+	versionLine := ""
+	if version != nil && len(a.state.versions) > 1 {
+		versionLine = i18n.T("result.version", version.Number, len(a.state.versions), version.CreatedAt.Format("Jan 2 15:04"))
+	} else if version == nil && !a.state.streaming && len(a.state.versions) > 0 && !a.state.resultIsPlan && a.state.anonymized == nil {
+		versionLine = i18n.T("result.versions_saved", len(a.state.versions))
+	}
+	if versionLine != "" {
+		line := lipgloss.NewStyle().Foreground(colorMuted).Render(versionLine)
+		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, line))
+		b.WriteString("\n")
+	}
+	if (a.state.currentIntent != nil || version != nil) && a.state.anonymized == nil {
+		b.WriteString("\n")
 	}
 
 	// Result box
@@ -30,15 +60,31 @@ func (a *App) renderResult() string {
 	}
 
 	// Calculate max height for result (account for input box when not streaming)
-	maxResultHeight := a.height - 14
+	maxResultHeight := height - 14
 	if a.state.streaming {
-		maxResultHeight = a.height - 10
+		maxResultHeight = height - 10
+	}
+	if versionLine != "" {
+		maxResultHeight--
+	}
+	check := a.currentVerification()
+	if check != nil {
+		maxResultHeight--
 	}
 	if maxResultHeight < 5 {
 		maxResultHeight = 5
 	}
 	resultLines := strings.Split(result, "\n")
-	if len(resultLines) > maxResultHeight {
+	if a.state.resultIsPlan && len(resultLines) > maxResultHeight {
+		// Dry run plans are read top-down: summary first, then prompts
+		more := len(resultLines) - maxResultHeight + 1
+		resultLines = append(resultLines[:maxResultHeight-1], i18n.T("result.plan_more", more))
+		result = strings.Join(resultLines, "\n")
+	} else if a.state.anonymized != nil && len(resultLines) > maxResultHeight {
+		more := len(resultLines) - maxResultHeight + 1
+		resultLines = append(resultLines[:maxResultHeight-1], i18n.T("result.anonymized_more", more))
+		result = strings.Join(resultLines, "\n")
+	} else if len(resultLines) > maxResultHeight {
 		// Show last N lines when streaming
 		resultLines = resultLines[len(resultLines)-maxResultHeight:]
 		result = strings.Join(resultLines, "\n")
@@ -52,13 +98,27 @@ func (a *App) renderResult() string {
 		resultStyle = resultStyle.BorderForeground(colorSecondary)
 	}
 
+	if check != nil {
+		result = highlightUnverified(result, check)
+	}
+
 	resultBox := resultStyle.Render(result)
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, resultBox))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if check != nil {
+		color := colorSuccess
+		if len(check.Missing()) > 0 {
+			color = colorError
+		}
+		summary := lipgloss.NewStyle().Foreground(color).Render(truncate(verifySummary(check), 70))
+		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, summary))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Input for follow-up (only show when not streaming)
-	if !a.state.streaming {
-		a.state.input.Placeholder = "Follow-up or revision..."
+	if !a.state.streaming && !a.state.resultIsPlan && a.state.anonymized == nil {
+		a.state.input.Placeholder = i18n.T("result.placeholder")
 		inputBox := styleBox.Copy().
 			Width(min(70, a.width-4)).
 			BorderForeground(colorMuted).
@@ -67,14 +127,27 @@ func (a *App) renderResult() string {
 		b.WriteString("\n\n")
 	}
 
+	// Save/export feedback
+	if a.state.notice != "" && !a.state.streaming {
+		notice := lipgloss.NewStyle().
+			Foreground(colorSuccess).
+			Render(truncate(a.state.notice, 70))
+		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, notice))
+		b.WriteString("\n")
+	}
+
 	// Status bar
 	var status string
 	if a.state.streaming {
-		status = styleStatusBar.Render("Streaming... [Esc] Cancel")
+		status = styleStatusBar.Render(i18n.T("result.streaming_keys"))
+	} else if a.state.resultIsPlan {
+		status = styleStatusBar.Render(i18n.T("result.plan_keys"))
+	} else if a.state.anonymized != nil {
+		status = styleStatusBar.Render(i18n.T("result.anonymized_keys"))
 	} else {
-		status = styleStatusBar.Render("[Enter] Submit  [c] Copy  [s] Save  [n] New document  [Esc] Quit")
+		status = styleStatusBar.Render(i18n.T("result.keys"))
 	}
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, status))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
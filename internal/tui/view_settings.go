@@ -2,33 +2,39 @@ package tui
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/i18n"
+	"github.com/sant0-9/pulp/internal/llm"
 )
 
-func (a *App) renderSettings() string {
+func (a *App) renderSettings(height int) string {
 	switch a.state.settingsMode {
 	case "provider":
-		return a.renderSettingsProvider()
+		return a.renderSettingsProvider(height)
 	case "model":
-		return a.renderSettingsModel()
+		return a.renderSettingsModel(height)
 	case "apikey":
-		return a.renderSettingsAPIKey()
+		return a.renderSettingsAPIKey(height)
+	case "generation":
+		return a.renderSettingsGeneration(height)
 	default:
-		return a.renderSettingsMain()
+		return a.renderSettingsMain(height)
 	}
 }
 
-func (a *App) renderSettingsMain() string {
+func (a *App) renderSettingsMain(height int) string {
 	var b strings.Builder
 
 	// Title
 	title := lipgloss.NewStyle().
 		Foreground(colorPrimary).
 		Bold(true).
-		Render("Settings")
+		Render(i18n.T("settings.title"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
@@ -40,7 +46,7 @@ func (a *App) renderSettingsMain() string {
 	}
 
 	// Mask API key
-	maskedKey := "Not set"
+	maskedKey := i18n.T("settings.not_set")
 	if a.state.config.APIKey != "" {
 		if len(a.state.config.APIKey) > 8 {
 			maskedKey = a.state.config.APIKey[:4] + "****" + a.state.config.APIKey[len(a.state.config.APIKey)-4:]
@@ -50,16 +56,20 @@ func (a *App) renderSettingsMain() string {
 	}
 
 	configLines := []string{
-		fmt.Sprintf("  Provider: %s", providerName),
-		fmt.Sprintf("  Model:    %s", a.state.config.Model),
-		fmt.Sprintf("  API Key:  %s", maskedKey),
+		"  " + i18n.T("settings.provider", providerName),
+		"  " + i18n.T("settings.model", a.state.config.Model),
+		"  " + i18n.T("settings.api_key", maskedKey),
+		"  " + i18n.T("settings.capabilities", capabilityList(llm.CapabilitiesOf(a.state.provider))),
 	}
 
+	gen := a.state.config.GenerationSettings()
+	configLines = append(configLines, "", "  "+i18n.T("settings.generation_summary", gen.Temperature, gen.MaxTokens, onOff(gen.Stream)))
+
 	if a.state.config.Local != nil && a.state.config.Local.Enabled {
 		configLines = append(configLines, "")
-		configLines = append(configLines, "  Local Model:")
-		configLines = append(configLines, fmt.Sprintf("    Provider: %s", a.state.config.Local.Provider))
-		configLines = append(configLines, fmt.Sprintf("    Model:    %s", a.state.config.Local.Model))
+		configLines = append(configLines, "  "+i18n.T("settings.local_model"))
+		configLines = append(configLines, "    "+i18n.T("settings.provider", a.state.config.Local.Provider))
+		configLines = append(configLines, "    "+i18n.T("settings.model", a.state.config.Local.Model))
 	}
 
 	configBox := styleBox.Copy().
@@ -70,10 +80,11 @@ func (a *App) renderSettingsMain() string {
 
 	// Actions
 	actions := []string{
-		"  [p] Change provider",
-		"  [m] Change model",
-		"  [k] Update API key",
-		"  [r] Reset setup",
+		"  " + i18n.T("settings.change_provider"),
+		"  " + i18n.T("settings.change_model"),
+		"  " + i18n.T("settings.update_key"),
+		"  " + i18n.T("settings.change_generation"),
+		"  " + i18n.T("settings.reset"),
 	}
 	actionsBox := styleBox.Copy().
 		Width(50).
@@ -82,19 +93,19 @@ func (a *App) renderSettingsMain() string {
 	b.WriteString("\n\n")
 
 	// Instructions
-	instructions := styleStatusBar.Render("[Esc] Back")
+	instructions := styleStatusBar.Render(i18n.T("common.back_keys"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, instructions))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
 
-func (a *App) renderSettingsProvider() string {
+func (a *App) renderSettingsProvider(height int) string {
 	var b strings.Builder
 
 	title := lipgloss.NewStyle().
 		Foreground(colorPrimary).
 		Bold(true).
-		Render("Select Provider")
+		Render(i18n.T("settings.select_provider"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
@@ -117,30 +128,30 @@ func (a *App) renderSettingsProvider() string {
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, listBox))
 	b.WriteString("\n\n")
 
-	instructions := styleStatusBar.Render("[Up/Down] Navigate  [Enter] Select  [Esc] Cancel")
+	instructions := styleStatusBar.Render(i18n.T("settings.list_keys"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, instructions))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
 
-func (a *App) renderSettingsModel() string {
+func (a *App) renderSettingsModel(height int) string {
 	var b strings.Builder
 
 	title := lipgloss.NewStyle().
 		Foreground(colorPrimary).
 		Bold(true).
-		Render("Select Model")
+		Render(i18n.T("settings.select_model"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
 	provider := config.GetProvider(a.state.config.Provider)
 	if provider == nil {
-		desc := styleSubtitle.Render("No provider selected")
+		desc := styleSubtitle.Render(i18n.T("settings.no_provider"))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, desc))
-		return a.centerVertically(b.String())
+		return a.centerVertically(b.String(), height)
 	}
 
-	providerDesc := styleSubtitle.Render(fmt.Sprintf("Provider: %s", provider.Name))
+	providerDesc := styleSubtitle.Render(i18n.T("settings.provider", provider.Name))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, providerDesc))
 	b.WriteString("\n\n")
 
@@ -153,7 +164,7 @@ func (a *App) renderSettingsModel() string {
 		// Mark current model
 		current := ""
 		if model == a.state.config.Model {
-			current = " (current)"
+			current = " " + i18n.T("settings.current")
 		}
 		line := fmt.Sprintf("%s%s%s", cursor, model, current)
 		if i == a.state.settingsSelected {
@@ -168,23 +179,23 @@ func (a *App) renderSettingsModel() string {
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, listBox))
 	b.WriteString("\n\n")
 
-	instructions := styleStatusBar.Render("[Up/Down] Navigate  [Enter] Select  [Esc] Cancel")
+	instructions := styleStatusBar.Render(i18n.T("settings.list_keys"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, instructions))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
 
-func (a *App) renderSettingsAPIKey() string {
+func (a *App) renderSettingsAPIKey(height int) string {
 	var b strings.Builder
 
 	title := lipgloss.NewStyle().
 		Foreground(colorPrimary).
 		Bold(true).
-		Render("Update API Key")
+		Render(i18n.T("settings.update_key_title"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
-	desc := styleSubtitle.Render("Enter your new API key")
+	desc := styleSubtitle.Render(i18n.T("settings.enter_new_key"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, desc))
 	b.WriteString("\n\n")
 
@@ -195,8 +206,152 @@ func (a *App) renderSettingsAPIKey() string {
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, inputBox))
 	b.WriteString("\n\n")
 
-	instructions := styleStatusBar.Render("[Enter] Save  [Esc] Cancel")
+	instructions := styleStatusBar.Render(i18n.T("settings.key_keys"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, instructions))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
+}
+
+// Generation fields in page order
+var generationFields = []string{"temperature", "max_tokens", "stream"}
+
+// Max output token steps offered on the generation page
+var maxTokenSteps = []int{256, 512, 1024, 2048, 4096, 8192, 16384}
+
+// adjustGeneration moves the selected field one step in dir (-1 or 1)
+func adjustGeneration(g *config.GenerationSettings, field, dir int) {
+	switch generationFields[field] {
+	case "temperature":
+		t := g.Temperature + 0.1*float64(dir)
+		// Keep one decimal so repeated steps don't drift
+		g.Temperature = math.Round(math.Max(0, math.Min(2, t))*10) / 10
+	case "max_tokens":
+		i := sort.SearchInts(maxTokenSteps, g.MaxTokens)
+		if dir < 0 {
+			i--
+		} else if i < len(maxTokenSteps) && maxTokenSteps[i] == g.MaxTokens {
+			i++
+		}
+		i = max(0, min(len(maxTokenSteps)-1, i))
+		g.MaxTokens = maxTokenSteps[i]
+	case "stream":
+		g.Stream = !g.Stream
+	}
+}
+
+func onOff(b bool) string {
+	if b {
+		return i18n.T("settings.on")
+	}
+	return i18n.T("settings.off")
+}
+
+func (a *App) renderSettingsGeneration(height int) string {
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().
+		Foreground(colorPrimary).
+		Bold(true).
+		Render(i18n.T("settings.generation_title"))
+	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
+	b.WriteString("\n\n")
+
+	g := a.state.genDraft
+	values := []string{
+		fmt.Sprintf("%.1f", g.Temperature),
+		fmt.Sprintf("%d", g.MaxTokens),
+		onOff(g.Stream),
+	}
+
+	var lines []string
+	for i, field := range generationFields {
+		cursor := "  "
+		if i == a.state.settingsSelected {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%s%-14s < %s >", cursor, i18n.T("settings.gen."+field), values[i])
+		if i == a.state.settingsSelected {
+			line = lipgloss.NewStyle().Foreground(colorPrimary).Bold(true).Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	listBox := styleBox.Copy().
+		Width(50).
+		Render(strings.Join(lines, "\n"))
+	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, listBox))
+	b.WriteString("\n\n")
+
+	// Live preview of what the selected value does
+	preview := styleBox.Copy().
+		Width(50).
+		BorderForeground(colorSecondary).
+		Render(generationPreview(g, generationFields[a.state.settingsSelected], llm.CapabilitiesOf(a.state.provider)))
+	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, preview))
+	b.WriteString("\n\n")
+
+	instructions := styleStatusBar.Render(i18n.T("settings.generation_keys"))
+	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, instructions))
+
+	return a.centerVertically(b.String(), height)
+}
+
+// capabilityList names what the current model supports
+func capabilityList(c llm.Capabilities) string {
+	var can []string
+	for _, f := range []struct {
+		ok  bool
+		key string
+	}{
+		{c.Streaming, "settings.cap.streaming"},
+		{c.JSONMode, "settings.cap.json"},
+		{c.Vision, "settings.cap.vision"},
+		{c.Tools, "settings.cap.tools"},
+	} {
+		if f.ok {
+			can = append(can, i18n.T(f.key))
+		}
+	}
+	if c.MaxOutputTokens > 0 {
+		can = append(can, i18n.T("settings.cap.output", c.MaxOutputTokens))
+	}
+	if len(can) == 0 {
+		return i18n.T("settings.cap.unknown")
+	}
+	return strings.Join(can, ", ")
+}
+
+// generationPreview describes the effect of the current value of field,
+// and what the model does instead when it can't follow it
+func generationPreview(g config.GenerationSettings, field string, caps llm.Capabilities) string {
+	switch field {
+	case "temperature":
+		switch {
+		case g.Temperature <= 0.3:
+			return i18n.T("settings.preview.temp_focused")
+		case g.Temperature <= 0.8:
+			return i18n.T("settings.preview.temp_balanced")
+		case g.Temperature <= 1.2:
+			return i18n.T("settings.preview.temp_creative")
+		default:
+			return i18n.T("settings.preview.temp_random")
+		}
+	case "max_tokens":
+		// ~0.75 words per token, ~500 words per page
+		words := g.MaxTokens * 3 / 4
+		preview := i18n.T("settings.preview.max_tokens", words, max(1, words/500))
+		if caps.OutputTokens(g.MaxTokens) < g.MaxTokens {
+			preview += " " + i18n.T("settings.preview.max_tokens_capped", caps.MaxOutputTokens)
+		}
+		return preview
+	case "stream":
+		if g.Stream && !caps.Streaming {
+			return i18n.T("settings.preview.stream_unsupported")
+		}
+		if g.Stream {
+			return i18n.T("settings.preview.stream_on")
+		}
+		return i18n.T("settings.preview.stream_off")
+	}
+	return ""
 }
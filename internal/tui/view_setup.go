@@ -6,20 +6,21 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sant0-9/pulp/internal/config"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
-func (a *App) renderSetup() string {
+func (a *App) renderSetup(height int) string {
 	switch a.state.setupStep {
 	case 0:
-		return a.renderProviderSelection()
+		return a.renderProviderSelection(height)
 	case 1:
-		return a.renderAPIKeyEntry()
+		return a.renderAPIKeyEntry(height)
 	default:
 		return ""
 	}
 }
 
-func (a *App) renderProviderSelection() string {
+func (a *App) renderProviderSelection(height int) string {
 	var b strings.Builder
 
 	// Header
@@ -31,7 +32,7 @@ func (a *App) renderProviderSelection() string {
 	title := lipgloss.NewStyle().
 		Foreground(colorWhite).
 		Bold(true).
-		Render("Welcome! Choose your LLM provider:")
+		Render(i18n.T("setup.choose_provider"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
@@ -61,13 +62,13 @@ func (a *App) renderProviderSelection() string {
 	b.WriteString("\n\n")
 
 	// Instructions
-	instructions := styleStatusBar.Render("[j/k] Navigate  [Enter] Select")
+	instructions := styleStatusBar.Render(i18n.T("setup.provider_keys"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, instructions))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
 
-func (a *App) renderAPIKeyEntry() string {
+func (a *App) renderAPIKeyEntry(height int) string {
 	var b strings.Builder
 
 	provider := config.GetProvider(a.state.config.Provider)
@@ -81,13 +82,13 @@ func (a *App) renderAPIKeyEntry() string {
 	title := lipgloss.NewStyle().
 		Foreground(colorWhite).
 		Bold(true).
-		Render(fmt.Sprintf("Enter your %s API key:", provider.Name))
+		Render(i18n.T("setup.enter_key", provider.Name))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
 	// Signup link
 	if provider.SignupURL != "" {
-		link := styleSubtitle.Render(fmt.Sprintf("Get one at: %s", provider.SignupURL))
+		link := styleSubtitle.Render(i18n.T("setup.signup", provider.SignupURL))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, link))
 		b.WriteString("\n\n")
 	}
@@ -101,15 +102,15 @@ func (a *App) renderAPIKeyEntry() string {
 	b.WriteString("\n\n")
 
 	// Instructions
-	instructions := styleStatusBar.Render("[Enter] Continue  [Esc] Back")
+	instructions := styleStatusBar.Render(i18n.T("setup.key_keys"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, instructions))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
 
-func (a *App) centerVertically(content string) string {
+func (a *App) centerVertically(content string, height int) string {
 	lines := strings.Count(content, "\n") + 1
-	padding := (a.height - lines) / 2
+	padding := (height - lines) / 2
 	if padding < 0 {
 		padding = 0
 	}
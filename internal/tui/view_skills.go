@@ -5,18 +5,19 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
-func (a *App) renderSkills() string {
+func (a *App) renderSkills(height int) string {
 	var b strings.Builder
 
 	// Header
-	title := styleLogo.Render("Available Skills")
+	title := styleLogo.Render(i18n.T("skills.title"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
 	// Description
-	desc := styleSubtitle.Render("Skills provide specialized instructions for document processing")
+	desc := styleSubtitle.Render(i18n.T("skills.subtitle"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, desc))
 	b.WriteString("\n\n")
 
@@ -25,7 +26,7 @@ func (a *App) renderSkills() string {
 		noSkills := styleBox.Copy().
 			Width(min(70, a.width-4)).
 			Foreground(colorMuted).
-			Render("No skills installed.\n\nCreate skills in: ~/.config/pulp/skills/\n\nEach skill is a folder with SKILL.md")
+			Render(i18n.T("skills.none"))
 		b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, noSkills))
 	} else {
 		var skillList strings.Builder
@@ -51,13 +52,13 @@ func (a *App) renderSkills() string {
 	b.WriteString("\n\n")
 
 	// Usage hint
-	usage := styleSubtitle.Render("Use /skill-name to invoke a skill, or let pulp auto-match")
+	usage := styleSubtitle.Render(i18n.T("skills.usage"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, usage))
 	b.WriteString("\n\n")
 
 	// Status bar
-	statusBar := styleStatusBar.Render("[Esc] Back")
+	statusBar := styleStatusBar.Render(i18n.T("common.back_keys"))
 	b.WriteString(lipgloss.PlaceHorizontal(a.width, lipgloss.Center, statusBar))
 
-	return a.centerVertically(b.String())
+	return a.centerVertically(b.String(), height)
 }
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sant0-9/pulp/internal/converter"
+	"github.com/sant0-9/pulp/internal/i18n"
 )
 
 const logo = `
@@ -16,37 +18,51 @@ const logo = `
  ╚═╝      ╚═════╝ ╚══════╝╚═╝
 `
 
-func (a *App) renderWelcome() string {
+func (a *App) renderWelcome(height int) string {
 	// Logo
 	logoRendered := styleLogo.Render(logo)
 
 	// Subtitle
-	subtitle := styleSubtitle.Render("Document Intelligence")
+	subtitle := styleSubtitle.Render(i18n.T("welcome.subtitle"))
 
 	// Provider status
 	var status string
-	if a.state.loadingDoc {
-		status = styleSubtitle.Render("Loading document...")
+	if a.state.loadingDoc && converter.IsAudio(a.state.documentPath) {
+		status = styleSubtitle.Render(i18n.T("welcome.transcribing"))
+	} else if a.state.loadingDoc {
+		status = styleSubtitle.Render(i18n.T("welcome.loading"))
 	} else if a.state.docError != nil {
 		status = lipgloss.NewStyle().
 			Foreground(colorError).
-			Render("Error: " + truncate(a.state.docError.Error(), 50))
+			Render(i18n.T("common.error", truncate(a.state.docError.Error(), 50)))
 	} else if a.state.providerError != nil {
 		errorLine := lipgloss.NewStyle().
 			Foreground(colorError).
-			Render("Provider error: " + truncate(a.state.providerError.Error(), 40))
+			Render(i18n.T("welcome.provider_error", truncate(a.state.providerError.Error(), 40)))
 		hint := lipgloss.NewStyle().
 			Foreground(colorMuted).
 			MarginTop(1).
-			Render("Press [s] for settings to fix")
+			Render(i18n.T("welcome.provider_error_hint"))
 		status = lipgloss.JoinVertical(lipgloss.Center, errorLine, hint)
 	} else if a.state.providerReady {
 		modelName := a.getModelDisplayName()
+		ready := i18n.T("welcome.ready", modelName)
+		if a.state.dryRun {
+			ready = i18n.T("welcome.ready_dry_run", modelName)
+		}
 		status = lipgloss.NewStyle().
 			Foreground(colorSuccess).
-			Render(fmt.Sprintf("Ready - %s", modelName))
+			Render(ready)
 	} else {
-		status = styleSubtitle.Render("Connecting...")
+		status = styleSubtitle.Render(i18n.T("welcome.connecting"))
+	}
+
+	// Feedback from the last action (e.g. a saved chat summary)
+	if a.state.notice != "" && a.state.docError == nil {
+		notice := lipgloss.NewStyle().
+			Foreground(colorSuccess).
+			Render(truncate(a.state.notice, 70))
+		status = lipgloss.JoinVertical(lipgloss.Center, status, notice)
 	}
 
 	// Input (only show if ready)
@@ -71,7 +87,7 @@ func (a *App) renderWelcome() string {
 	}
 
 	// Status bar
-	statusBar := styleStatusBar.Render("[s] Settings  [?] Help  [Esc] Quit")
+	statusBar := styleStatusBar.Render(i18n.T("welcome.keys"))
 
 	// Combine main content
 	content := lipgloss.JoinVertical(
@@ -87,7 +103,7 @@ func (a *App) renderWelcome() string {
 	// Center content on screen (leave room for status bar)
 	mainArea := lipgloss.Place(
 		a.width,
-		a.height-2,
+		height-2,
 		lipgloss.Center,
 		lipgloss.Center,
 		content,
@@ -132,7 +148,7 @@ func (a *App) renderCommandPalette() string {
 	hint := lipgloss.NewStyle().
 		Foreground(colorMuted).
 		Italic(true).
-		Render("  [Up/Down] Navigate  [Tab] Complete  [Enter] Select")
+		Render("  " + i18n.T("palette.keys"))
 	lines = append(lines, "", hint)
 
 	return lipgloss.NewStyle().
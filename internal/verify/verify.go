@@ -0,0 +1,250 @@
+// Package verify cross-checks a result against its source document:
+// every number and named entity in the result is looked up in the
+// source, so figures and names the model made up stand out.
+package verify
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Status of a checked item
+type Status int
+
+const (
+	Found   Status = iota // In the source as written
+	Rounded               // A number within 5% of one in the source
+	Missing               // Not in the source
+)
+
+// Item is a number or name from the result
+type Item struct {
+	Text   string
+	Kind   string // "number" or "name"
+	Status Status
+	Source int // Index of the source text it was found in, -1 if missing
+}
+
+// Report lists the checked items in order of appearance, each once
+type Report struct {
+	Items []Item
+}
+
+// Missing returns the items not found in the source
+func (r *Report) Missing() []Item {
+	var items []Item
+	for _, it := range r.Items {
+		if it.Status == Missing {
+			items = append(items, it)
+		}
+	}
+	return items
+}
+
+// Count returns how many items have status s
+func (r *Report) Count(s Status) int {
+	n := 0
+	for _, it := range r.Items {
+		if it.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+// Check looks up every number and name in result in the source texts
+// (usually the document's chunks)
+func Check(result string, sources []string) *Report {
+	var values [][]float64
+	var lower []string
+	for _, s := range sources {
+		values = append(values, numberValues(s))
+		lower = append(lower, strings.ToLower(s))
+	}
+
+	report := &Report{}
+	seen := make(map[string]bool)
+	add := func(it Item) {
+		if seen[it.Kind+it.Text] {
+			return
+		}
+		seen[it.Kind+it.Text] = true
+		report.Items = append(report.Items, it)
+	}
+
+	text := cleanMarkdown(result)
+	for _, n := range findNumbers(text) {
+		it := Item{Text: n.text, Kind: "number", Status: Missing, Source: -1}
+		for i, vs := range values {
+			if st := matchValue(n, vs); st < it.Status {
+				it.Status, it.Source = st, i
+				if st == Found {
+					break
+				}
+			}
+		}
+		add(it)
+	}
+	for _, name := range findNames(text) {
+		it := Item{Text: name, Kind: "name", Status: Missing, Source: -1}
+		for i, s := range lower {
+			if strings.Contains(s, strings.ToLower(name)) {
+				it.Status, it.Source = Found, i
+				break
+			}
+		}
+		add(it)
+	}
+	return report
+}
+
+// matchValue compares n against the source's numbers. Only figures
+// written as rounded (4.2M, 12.5%) may match approximately, so a wrong
+// year or count is still missing.
+func matchValue(n number, source []float64) Status {
+	v := n.value
+	best := Missing
+	for _, s := range source {
+		switch {
+		case v == s || math.Abs(v-s) <= 1e-9*math.Max(math.Abs(v), math.Abs(s)):
+			return Found
+		case n.rounded && s != 0 && math.Abs(v-s)/math.Abs(s) <= 0.05:
+			best = Rounded
+		}
+	}
+	return best
+}
+
+var (
+	// $1,200.50  4.2M  45%  3 billion  €12k
+	numberPattern = regexp.MustCompile(`(?i)[$€£¥]?(\d{1,3}(?:,\d{3})+|\d+)(\.\d+)?(?:\s?(%|percent\b|k\b|m\b|mn\b|bn\b|b\b|thousand\b|million\b|billion\b|trillion\b))?`)
+
+	// List markers and footnote references aren't figures
+	listMarker  = regexp.MustCompile(`(?m)^\s*\d+[.)]\s`)
+	footnoteRef = regexp.MustCompile(`\[\^?\d+\]`)
+)
+
+var scales = map[string]float64{
+	"k": 1e3, "thousand": 1e3,
+	"m": 1e6, "mn": 1e6, "million": 1e6,
+	"b": 1e9, "bn": 1e9, "billion": 1e9,
+	"trillion": 1e12,
+}
+
+type number struct {
+	text    string
+	value   float64
+	rounded bool // Has a fraction or scale word, so may be rounded
+}
+
+func findNumbers(text string) []number {
+	text = listMarker.ReplaceAllString(text, " ")
+	text = footnoteRef.ReplaceAllString(text, " ")
+
+	var numbers []number
+	for _, m := range numberPattern.FindAllStringSubmatchIndex(text, -1) {
+		// Skip digits inside words and identifiers (Q3, v2, H2O)
+		if m[0] > 0 && isWordByte(text[m[0]-1]) {
+			continue
+		}
+		if m[1] < len(text) && isWordByte(text[m[1]]) {
+			continue
+		}
+		digits := strings.ReplaceAll(text[m[2]:m[3]], ",", "")
+		rounded := m[4] >= 0
+		if rounded {
+			digits += text[m[4]:m[5]]
+		}
+		v, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			continue
+		}
+		if m[6] >= 0 {
+			rounded = true
+			if s, ok := scales[strings.ToLower(text[m[6]:m[7]])]; ok {
+				v *= s
+			}
+		}
+		numbers = append(numbers, number{text: strings.TrimSpace(text[m[0]:m[1]]), value: v, rounded: rounded})
+	}
+	return numbers
+}
+
+func numberValues(text string) []float64 {
+	var values []float64
+	for _, n := range findNumbers(text) {
+		values = append(values, n.value)
+	}
+	return values
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// Capitalized words in a row: Acme Holdings, Jane Doe, FDA
+var namePattern = regexp.MustCompile(`\p{Lu}[\p{L}\d&'’-]*(?:[ \t]+\p{Lu}[\p{L}\d&'’-]*)*`)
+
+// Capitalized words that aren't names
+var notNames = map[string]bool{
+	"I": true, "A": true, "An": true, "The": true, "This": true, "That": true, "These": true, "Those": true,
+	"It": true, "Its": true, "We": true, "Our": true, "You": true, "Your": true, "They": true, "Their": true,
+	"He": true, "She": true, "His": true, "Her": true, "In": true, "On": true, "At": true, "For": true,
+	"And": true, "But": true, "Or": true, "If": true, "As": true, "By": true, "To": true, "Of": true,
+	"With": true, "From": true, "No": true, "Not": true, "All": true, "Some": true, "Each": true,
+}
+
+// findNames returns capitalized phrases that aren't just the first word
+// of a sentence. Headings are skipped: they're the model's own labels.
+func findNames(text string) []string {
+	var names []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") || strings.HasSuffix(trimmed, ":") {
+			continue
+		}
+		for _, m := range namePattern.FindAllStringIndex(line, -1) {
+			words := strings.Fields(line[m[0]:m[1]])
+			sentenceStart := startsSentence(line[:m[0]])
+			// Drop leading function words ("The Board" -> "Board")
+			for len(words) > 0 && notNames[words[0]] {
+				words = words[1:]
+				sentenceStart = false
+			}
+			if len(words) == 0 || (sentenceStart && len(words) == 1) {
+				continue
+			}
+			if len(words) == 1 && notNames[words[0]] {
+				continue
+			}
+			name := strings.Join(words, " ")
+			name = strings.TrimSuffix(strings.TrimSuffix(name, "'s"), "’s")
+			names = append(names, strings.Trim(name, "-'’"))
+		}
+	}
+	return names
+}
+
+// startsSentence reports whether a word after prefix starts a sentence
+// or a list item
+func startsSentence(prefix string) bool {
+	p := strings.TrimRight(prefix, " \t\"'(“")
+	if strings.Trim(p, "-*+>|0123456789.) \t") == "" {
+		return true
+	}
+	last := p[len(p)-1]
+	return last == '.' || last == '!' || last == '?' || last == ':' || last == '|'
+}
+
+// Markdown emphasis and link targets hide or add text
+var (
+	emphasis   = regexp.MustCompile("[*_`]+")
+	linkTarget = regexp.MustCompile(`\]\([^)]*\)`)
+)
+
+func cleanMarkdown(text string) string {
+	text = linkTarget.ReplaceAllString(text, "]")
+	return emphasis.ReplaceAllString(text, "")
+}
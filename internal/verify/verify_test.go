@@ -0,0 +1,37 @@
+package verify
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	sources := []string{
+		"Acme Holdings reported revenue of $4,213,000 in 2023, up 12% on the prior year.",
+		"The board, chaired by Jane Doe, approved 3 new stores in Lyon.",
+	}
+	result := `## Key Points
+
+1. **Acme Holdings** grew revenue to $4.2M in 2023 (+12%).
+2. Jane Doe's board approved 5 new stores in Lyon and Paris.
+3. Globex Corp remains the main competitor, per the Q3 update.`
+
+	report := Check(result, sources)
+
+	var missing []string
+	for _, it := range report.Missing() {
+		missing = append(missing, it.Text)
+	}
+	want := []string{"5", "Paris", "Globex Corp", "Q3"}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("missing = %q, want %q", missing, want)
+	}
+	if n := report.Count(Rounded); n != 1 {
+		t.Errorf("rounded = %d, want 1 ($4.2M)", n)
+	}
+	for _, it := range report.Items {
+		if it.Text == "Jane Doe" && it.Source != 1 {
+			t.Errorf("Jane Doe found in source %d, want 1", it.Source)
+		}
+	}
+}
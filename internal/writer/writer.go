@@ -3,6 +3,7 @@ package writer
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/sant0-9/pulp/internal/intent"
 	"github.com/sant0-9/pulp/internal/llm"
@@ -12,18 +13,37 @@ import (
 
 // Writer generates final output from aggregated content
 type Writer struct {
-	provider llm.Provider
-	model    string
+	provider    llm.Provider
+	model       string
+	maxTokens   int
+	temperature float64
+	preamble    string
+	canceler    llm.Canceler
 }
 
 // NewWriter creates a new writer
 func NewWriter(provider llm.Provider, model string) *Writer {
 	return &Writer{
-		provider: provider,
-		model:    model,
+		provider:    provider,
+		model:       model,
+		maxTokens:   4096,
+		temperature: 0.7,
 	}
 }
 
+// SetParams overrides the default temperature and output token limit
+func (w *Writer) SetParams(temperature float64, maxTokens int) {
+	w.temperature = temperature
+	if maxTokens > 0 {
+		w.maxTokens = maxTokens
+	}
+}
+
+// SetPreamble sets the user preamble put ahead of the system prompt
+func (w *Writer) SetPreamble(preamble string) {
+	w.preamble = preamble
+}
+
 // Message represents a conversation message
 type Message struct {
 	Role    string
@@ -38,20 +58,37 @@ type WriteRequest struct {
 	History        []Message
 	IsFollowUp     bool
 	PreviousResult string
-}
 
-// Write generates the final output (non-streaming)
-func (w *Writer) Write(ctx context.Context, req *WriteRequest) (string, error) {
-	messages := w.buildMessages(req)
+	// Section referenced by a follow-up ("expand on section 3"), with
+	// its chunks injected as source text for the revision
+	Section       *pipeline.Section
+	SectionChunks []pipeline.Chunk
+}
 
-	llmReq := &llm.CompletionRequest{
+// Request builds the completion request the writer sends. The token
+// limit is capped at what the model can write.
+func (w *Writer) Request(req *WriteRequest) *llm.CompletionRequest {
+	return &llm.CompletionRequest{
 		Model:       w.model,
-		Messages:    messages,
-		MaxTokens:   4096,
-		Temperature: 0.7,
+		Messages:    w.buildMessages(req),
+		MaxTokens:   llm.CapabilitiesOf(w.provider).OutputTokens(w.maxTokens),
+		Temperature: w.temperature,
 	}
+}
 
-	resp, err := w.provider.Complete(ctx, llmReq)
+// Cancel stops a Write or Stream in progress from another goroutine.
+// Write returns context.Canceled; a stream ends with it as its final
+// error event.
+func (w *Writer) Cancel() {
+	w.canceler.Cancel()
+}
+
+// Write generates the final output (non-streaming)
+func (w *Writer) Write(ctx context.Context, req *WriteRequest) (string, error) {
+	ctx, done := w.canceler.Start(ctx)
+	defer done()
+
+	resp, err := w.provider.Complete(ctx, w.Request(req))
 	if err != nil {
 		return "", err
 	}
@@ -59,33 +96,39 @@ func (w *Writer) Write(ctx context.Context, req *WriteRequest) (string, error) {
 	return resp.Content, nil
 }
 
-// Stream generates output with streaming
+// Stream generates output with streaming. Models that can't stream
+// deliver the whole output as one chunk.
 func (w *Writer) Stream(ctx context.Context, req *WriteRequest) (<-chan llm.StreamEvent, error) {
-	messages := w.buildMessages(req)
-
-	llmReq := &llm.CompletionRequest{
-		Model:       w.model,
-		Messages:    messages,
-		MaxTokens:   4096,
-		Temperature: 0.7,
+	ctx, done := w.canceler.Start(ctx)
+	events, err := llm.StreamOrComplete(ctx, w.provider, w.Request(req))
+	if err != nil {
+		done()
+		return nil, err
 	}
-
-	return w.provider.Stream(ctx, llmReq)
+	return llm.Relay(ctx, events, done), nil
 }
 
 func (w *Writer) buildMessages(req *WriteRequest) []llm.Message {
 	var messages []llm.Message
 
-	// Build system prompt with skill instructions if present
+	// Build system prompt with the user preamble and skill instructions
+	// if present
+	var system string
 	if req.Intent.HasSkill() {
+		system = prompts.BuildSkillPrompt(req.Intent.MatchedSkill.Body)
+	}
+	if system = prompts.WithPreamble(w.preamble, system); system != "" {
 		messages = append(messages, llm.Message{
 			Role:    "system",
-			Content: prompts.BuildSkillPrompt(req.Intent.MatchedSkill.Body),
+			Content: system,
 		})
 	}
 
-	// Get document content
-	docContent := req.Aggregated.FormatForWriter()
+	// Get document content (nil when planning a dry run, before extraction)
+	docContent := "[aggregated extraction results]"
+	if req.Aggregated != nil {
+		docContent = req.Aggregated.FormatForWriter()
+	}
 	if req.DocTitle != "" {
 		docContent = fmt.Sprintf("Document: %s\n\n%s", req.DocTitle, docContent)
 	}
@@ -93,6 +136,9 @@ func (w *Writer) buildMessages(req *WriteRequest) []llm.Message {
 	if req.IsFollowUp && req.PreviousResult != "" {
 		// Follow-up: include previous result for revision
 		userContent := fmt.Sprintf("Previous response:\n\n%s\n\n---\n\n%s", req.PreviousResult, req.Intent.RawPrompt)
+		if req.Section != nil && len(req.SectionChunks) > 0 {
+			userContent = fmt.Sprintf("%s\n\n---\n\n%s", formatSection(req.Section, req.SectionChunks), userContent)
+		}
 		messages = append(messages, llm.Message{
 			Role:    "user",
 			Content: userContent,
@@ -111,3 +157,19 @@ func (w *Writer) buildMessages(req *WriteRequest) []llm.Message {
 
 	return messages
 }
+
+// formatSection renders a referenced section's chunks as source text
+func formatSection(section *pipeline.Section, chunks []pipeline.Chunk) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Source text of section %s:\n\n", section.Label()))
+	for i, c := range chunks {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(c.Content)
+	}
+	if notes := pipeline.CollectFootnotes(chunks); len(notes) > 0 {
+		b.WriteString("\n\nFootnotes:\n" + pipeline.FormatFootnotes(notes))
+	}
+	return b.String()
+}